@@ -50,6 +50,13 @@ func init() {
 		"config file (default is ./config/food.yml)")
 	rootCmd.PersistentFlags().StringVarP(&logLevel, "log", "L",
 		defaultLogLevel, "Set log level")
+
+	rootCmd.PersistentFlags().String("cassette", "",
+		"HTTP cassette mode - off, record (save all responses) or replay (serve the saved responses)")
+	rootCmd.PersistentFlags().String("cassette-dir", "",
+		"HTTP cassette directory (default is ./runtime/cassettes)")
+	_ = viper.BindPFlag("cassette.mode", rootCmd.PersistentFlags().Lookup("cassette"))
+	_ = viper.BindPFlag("cassette.dir", rootCmd.PersistentFlags().Lookup("cassette-dir"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pestanko/miniscrape/internal/config"
 	"github.com/pestanko/miniscrape/internal/models"
 	"github.com/pestanko/miniscrape/internal/scraper"
+	"github.com/pestanko/miniscrape/internal/scraper/filters"
+	"github.com/pestanko/miniscrape/internal/scraper/resolvers"
 	"github.com/pestanko/miniscrape/pkg/applog"
 
 	"github.com/spf13/cobra"
@@ -16,6 +19,7 @@ var (
 	noCache     bool
 	noContent   bool
 	updateCache bool
+	targetDay   string
 )
 
 // scrapeCmd represents the scrape command
@@ -32,6 +36,19 @@ var scrapeCmd = &cobra.Command{
 		if updateCache {
 			cfg.Cache.Update = true
 		}
+		if err := resolvers.ConfigureCassette(cfg.Cassette); err != nil {
+			return err
+		}
+		resolvers.ConfigureFixtures(cfg.Fixtures)
+		if err := filters.ConfigureDay(cfg.Day); err != nil {
+			return err
+		}
+
+		day, err := cfg.Day.ParseTargetDay(targetDay, time.Now())
+		if err != nil {
+			return err
+		}
+		selector.Day = day
 
 		scrapeService := scraper.NewService(cfg)
 		results := scrapeService.Scrape(cmd.Context(), selector)
@@ -41,6 +58,12 @@ var scrapeCmd = &cobra.Command{
 				r.Page.Name,
 				r.Page.CodeName,
 				r.Page.Homepage)
+			if r.Source != "" {
+				fmt.Printf("Source: %s\n", r.Source)
+			}
+			if r.Error != nil {
+				fmt.Printf("Failed [%s]: %v\n", r.Error.Kind, r.Error.Err)
+			}
 			if !noContent {
 				fmt.Printf("%s\n\n", r.Content)
 			}
@@ -76,6 +99,9 @@ func init() {
 	scrapeCmd.PersistentFlags().BoolVar(&noContent, "no-content", false,
 		"Do not print out the content")
 
+	scrapeCmd.PersistentFlags().StringVarP(&targetDay, "day", "D", "",
+		"Target day of the content - today, tomorrow, yesterday or YYYY-MM-DD (default is today)")
+
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// scrapeCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
@@ -9,6 +9,8 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog/log"
 
+	"github.com/pestanko/miniscrape/internal/scraper/filters"
+	"github.com/pestanko/miniscrape/internal/scraper/resolvers"
 	"github.com/pestanko/miniscrape/internal/web"
 	"github.com/pestanko/miniscrape/pkg/applog"
 	"github.com/pestanko/miniscrape/pkg/rest/chiapp"
@@ -35,6 +37,14 @@ var serveCmd = &cobra.Command{
 		return run.Run(cmd.Context(), func(ctx context.Context, d *deps.Deps) error {
 			applog.InitGlobalLogger(&d.Cfg.Log)
 
+			if err := resolvers.ConfigureCassette(d.Cfg.Cassette); err != nil {
+				return err
+			}
+			resolvers.ConfigureFixtures(d.Cfg.Fixtures)
+			if err := filters.ConfigureDay(d.Cfg.Day); err != nil {
+				return err
+			}
+
 			server := web.NewServer(d.Cfg)
 
 			listenAddr := d.Cfg.Web.Addr
@@ -1,6 +1,9 @@
 package cache
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/pestanko/miniscrape/internal/config"
 	"github.com/pestanko/miniscrape/internal/models"
@@ -16,6 +19,14 @@ import (
 // content
 const DefaultContentFile = "content.txt"
 
+// responsesDir name of the directory where the last responses are stored,
+// the responses are not bound to the date
+const responsesDir = "responses"
+
+// responseFileExt extension of the files where the last responses are stored,
+// there is one file per request URL
+const responseFileExt = ".json"
+
 // NamespacePath defines a generic interface for each type to have method
 // to return the namespace path
 type NamespacePath interface {
@@ -33,6 +44,30 @@ type Item struct {
 	CachePolicy string
 }
 
+// IsCacheable whether the item can be stored based on its cache policy
+func (i Item) IsCacheable() bool {
+	return i.CachePolicy != "no-cache" && i.CachePolicy != "no"
+}
+
+// ResponseEntry last HTTP response for the URL of the page with its validators,
+// used to make conditional requests
+type ResponseEntry struct {
+	// URL of the request
+	URL string `json:"url"`
+	// ETag header of the response
+	ETag string `json:"etag"`
+	// LastModified header of the response
+	LastModified string `json:"lastModified"`
+	// ContentType header of the response
+	ContentType string `json:"contentType,omitempty"`
+	// Body of the response
+	Body []byte `json:"body"`
+	// Content processed from the body, empty if not resolved yet
+	Content string `json:"content"`
+	// ContentKey fingerprint of the page configuration the content has been processed with
+	ContentKey string `json:"contentKey,omitempty"`
+}
+
 // ItemNamespace contains tuple Category/Page
 type ItemNamespace struct {
 	// Page codename
@@ -69,6 +104,10 @@ type Cache interface {
 	GetContent(item Item) []byte
 	// Invalidate the cache content
 	Invalidate(sel models.RunSelector)
+	// GetResponse returns the last stored response for the URL in the namespace
+	GetResponse(nm ItemNamespace, url string) (ResponseEntry, bool)
+	// StoreResponse stores the last response for the entry URL in the namespace
+	StoreResponse(nm ItemNamespace, entry ResponseEntry) error
 }
 
 // NewCache creates an instance of the new cache
@@ -101,6 +140,7 @@ func (c *cacheFs) Invalidate(sel models.RunSelector) {
 	nm := NewNamespace(sel.Category, sel.Page)
 	pth := c.getNamespaceDir(nm.Path())
 	removeDir(pth)
+	removeDir(filepath.Join(c.rootDir, responsesDir, nm.Path()))
 }
 
 func (c *cacheFs) IsItemCached(item Item) bool {
@@ -130,17 +170,19 @@ func (c *cacheFs) GetContent(item Item) []byte {
 }
 
 func (c *cacheFs) IsPageCached(nm ItemNamespace) bool {
-	return !c.forceUpdate && isPathExists(c.getNamespaceDir(nm.Path()))
+	// the page is cached once its content is stored,
+	// other files of the page may be stored before the content
+	return c.IsItemCached(Item{Namespace: nm})
 }
 
 func (c *cacheFs) Store(item Item, content []byte) error {
-	if item.CachePolicy == "no-cache" || item.CachePolicy == "no" {
+	if !item.IsCacheable() {
 		return nil
 	}
 
 	fp := c.getFileForItem(item)
 
-	if !c.IsPageCached(item.Namespace) {
+	if !isPathExists(c.getNamespaceDir(item.Namespace.Path())) {
 		if err := os.MkdirAll(c.getNamespaceDir(item.Namespace.Path()), 0700); err != nil {
 			log.Error().
 				Err(err).
@@ -178,6 +220,60 @@ func (c *cacheFs) Store(item Item, content []byte) error {
 	return nil
 }
 
+func (c *cacheFs) GetResponse(nm ItemNamespace, url string) (ResponseEntry, bool) {
+	fp := c.getResponseFile(nm, url)
+	content, err := os.ReadFile(filepath.Clean(fp))
+	if err != nil {
+		return ResponseEntry{}, false
+	}
+
+	var entry ResponseEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		log.Warn().
+			Err(err).
+			Str("file", fp).
+			Str("type", "cache").
+			Msg("CACHE: Unable to load stored response")
+		return ResponseEntry{}, false
+	}
+	if entry.URL != url {
+		return ResponseEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (c *cacheFs) StoreResponse(nm ItemNamespace, entry ResponseEntry) error {
+	fp := c.getResponseFile(nm, entry.URL)
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fp), 0700); err != nil {
+		log.Error().
+			Err(err).
+			Str("file", fp).
+			Str("type", "cache").
+			Msg("CACHE: Unable to create directory file")
+		return err
+	}
+
+	log.Trace().
+		Str("item", nm.String()).
+		Str("file", fp).
+		Str("type", "cache").
+		Msg("CACHE: Writing response file")
+
+	return os.WriteFile(fp, content, 0600)
+}
+
+func (c *cacheFs) getResponseFile(nm ItemNamespace, url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.rootDir, responsesDir, nm.Path(), hex.EncodeToString(sum[:8])+responseFileExt)
+}
+
 func (c *cacheFs) GetDateDir() string {
 	return path.Join(c.rootDir, c.getDateDirName())
 }
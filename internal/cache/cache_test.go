@@ -20,3 +20,36 @@ func TestCreateACacheInstanceForDisabledIsNil(t *testing.T) {
 
 	assert.Nil(cache)
 }
+
+func TestStoreAndGetResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := NewCache(config.CacheCfg{
+		Enabled: true,
+		Root:    t.TempDir(),
+	}, time.Now())
+
+	nm := NewNamespace("food", "alvin")
+
+	_, ok := cache.GetResponse(nm, "https://example.com/menu")
+	assert.False(ok)
+
+	entry := ResponseEntry{
+		URL:          "https://example.com/menu",
+		ETag:         `"abc"`,
+		LastModified: "Sat, 17 Oct 2026 10:00:00 GMT",
+		Body:         []byte("<html></html>"),
+	}
+	assert.NoError(cache.StoreResponse(nm, entry))
+
+	other := ResponseEntry{URL: "https://example.com/menu/today", Body: []byte("<p>today</p>")}
+	assert.NoError(cache.StoreResponse(nm, other))
+
+	stored, ok := cache.GetResponse(nm, entry.URL)
+	assert.True(ok)
+	assert.Equal(entry, stored)
+
+	stored, ok = cache.GetResponse(nm, other.URL)
+	assert.True(ok)
+	assert.Equal(other, stored)
+}
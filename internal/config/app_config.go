@@ -2,8 +2,14 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	// embedded timezone database, the runtime image has no tzdata installed
+	_ "time/tzdata"
 
 	"github.com/pestanko/miniscrape/pkg/applog"
 	"github.com/pestanko/miniscrape/pkg/instrument"
@@ -20,6 +26,16 @@ type AppConfig struct {
 	Cache CacheCfg `json:"cache"`
 	// Web configuration
 	Web WebCfg `json:"web"`
+	// Fetch configuration of the outbound requests
+	Fetch FetchCfg `json:"fetch" yaml:"fetch"`
+	// Commands configuration of the content commands
+	Commands CommandsCfg `json:"commands" yaml:"commands"`
+	// Fixtures configuration of the local file sources
+	Fixtures FixturesCfg `json:"fixtures" yaml:"fixtures"`
+	// Cassette configuration of the HTTP recording and replaying
+	Cassette CassetteCfg `json:"cassette" yaml:"cassette"`
+	// Day configuration of the day filter
+	Day DayCfg `json:"day" yaml:"day"`
 	// Log configuration
 	Log applog.LogConfig `json:"log"`
 	// Otel OpenTelemetry configuration
@@ -38,6 +54,145 @@ type CacheCfg struct {
 	Root string `json:"root"`
 }
 
+// FetchCfg configuration of the outbound requests,
+// it can be overridden for each page
+type FetchCfg struct {
+	// Retries number of retries for transient failures, negative value disables retries
+	Retries int `json:"retries" yaml:"retries"`
+	// Backoff initial delay before the first retry, it doubles with each retry
+	Backoff time.Duration `json:"backoff" yaml:"backoff"`
+	// MaxBackoff maximal delay between two retries
+	MaxBackoff time.Duration `json:"maxBackoff" yaml:"maxBackoff"`
+	// HostInterval minimal interval between two requests to the same host
+	HostInterval time.Duration `json:"hostInterval" yaml:"hostInterval"`
+	// RetryNonIdempotent whether the requests other than GET/HEAD (ex. POST forms) are retried too
+	RetryNonIdempotent bool `json:"retryNonIdempotent" yaml:"retryNonIdempotent"`
+}
+
+// Override returns a copy of the configuration with non-zero values of other applied
+func (c FetchCfg) Override(other FetchCfg) FetchCfg {
+	if other.Retries != 0 {
+		c.Retries = other.Retries
+	}
+	if other.Backoff != 0 {
+		c.Backoff = other.Backoff
+	}
+	if other.MaxBackoff != 0 {
+		c.MaxBackoff = other.MaxBackoff
+	}
+	if other.HostInterval != 0 {
+		c.HostInterval = other.HostInterval
+	}
+	if other.RetryNonIdempotent {
+		c.RetryNonIdempotent = true
+	}
+	return c
+}
+
+// CommandsCfg configuration of the commands pages can use to get the content
+type CommandsCfg struct {
+	// Allowed list of executables the pages are allowed to run,
+	// if empty - no commands are allowed
+	Allowed []string `json:"allowed" yaml:"allowed"`
+}
+
+// IsAllowed whether the executable is allowed to run
+func (c CommandsCfg) IsAllowed(name string) bool {
+	return slices.Contains(c.Allowed, name)
+}
+
+// FixturesCfg configuration of the local file sources (file:// URLs and fixtures)
+type FixturesCfg struct {
+	// Root directory the local files have to be in, default "config"
+	Root string `json:"root" yaml:"root"`
+}
+
+// GetRoot returns the root directory of the local files
+func (c FixturesCfg) GetRoot() string {
+	if c.Root == "" {
+		return "config"
+	}
+	return c.Root
+}
+
+// Cassette modes of the outbound HTTP requests
+const (
+	// CassetteModeOff requests go to the network, nothing is recorded
+	CassetteModeOff = "off"
+	// CassetteModeRecord requests go to the network, responses are recorded
+	CassetteModeRecord = "record"
+	// CassetteModeReplay responses are served from the recordings, no network is used
+	CassetteModeReplay = "replay"
+)
+
+// CassetteCfg configuration of the HTTP recording and replaying
+type CassetteCfg struct {
+	// Mode of the outbound requests - off, record or replay, default off
+	Mode string `json:"mode" yaml:"mode"`
+	// Dir directory with the recorded request/response pairs
+	Dir string `json:"dir" yaml:"dir"`
+}
+
+// GetMode returns the cassette mode
+func (c CassetteCfg) GetMode() string {
+	if c.Mode == "" {
+		return CassetteModeOff
+	}
+	return c.Mode
+}
+
+// GetDir returns the directory with the recordings
+func (c CassetteCfg) GetDir() string {
+	if c.Dir == "" {
+		return "./runtime/cassettes"
+	}
+	return c.Dir
+}
+
+// DayCfg configuration of the day filter
+type DayCfg struct {
+	// Timezone used to determine the current day (ex. "Europe/Prague"), default - local timezone
+	Timezone string `json:"timezone" yaml:"timezone"`
+	// Locales day names (starting with Monday) per locale, they extend the built-in locales
+	Locales map[string][]string `json:"locales" yaml:"locales"`
+}
+
+// Location returns the configured timezone
+func (c DayCfg) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+// ParseTargetDay parses the target day - "today", "tomorrow", "yesterday"
+// or the date in the "2006-01-02" format, empty value is today
+func (c DayCfg) ParseTargetDay(value string, now time.Time) (time.Time, error) {
+	loc, err := c.Location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	day, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(value), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day %q - use today, tomorrow, yesterday or YYYY-MM-DD", value)
+	}
+
+	return day, nil
+}
+
 // WebCfg web config
 type WebCfg struct {
 	// Addr where the server should be running
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ScrapeErrorKind enum of the scrape error kinds
+type ScrapeErrorKind string
+
+const (
+	// ErrKindHTTPStatus the server responded with non-success status code
+	ErrKindHTTPStatus ScrapeErrorKind = "http_status"
+	// ErrKindTimeout the request or command timed out
+	ErrKindTimeout ScrapeErrorKind = "timeout"
+	// ErrKindDNS the host could not be resolved
+	ErrKindDNS ScrapeErrorKind = "dns"
+	// ErrKindParse the content could not be parsed
+	ErrKindParse ScrapeErrorKind = "parse"
+	// ErrKindSelectorMiss the query/xpath did not match any content
+	ErrKindSelectorMiss ScrapeErrorKind = "selector_miss"
+	// ErrKindCommand the content command failed
+	ErrKindCommand ScrapeErrorKind = "command"
+	// ErrKindAuth the login to the page failed
+	ErrKindAuth ScrapeErrorKind = "auth"
+	// ErrKindUnknown any other error
+	ErrKindUnknown ScrapeErrorKind = "unknown"
+)
+
+// ScrapeError typed error of the page scraping
+type ScrapeError struct {
+	// Kind of the error
+	Kind ScrapeErrorKind
+	// StatusCode of the HTTP response, only for the ErrKindHTTPStatus
+	StatusCode int
+	// Err the underlying error
+	Err error
+}
+
+// NewScrapeError creates a new instance of the scrape error
+func NewScrapeError(kind ScrapeErrorKind, err error) *ScrapeError {
+	return &ScrapeError{
+		Kind: kind,
+		Err:  err,
+	}
+}
+
+// NewHTTPStatusError creates a new instance of the error for non-success status code
+func NewHTTPStatusError(statusCode int, url string) *ScrapeError {
+	return &ScrapeError{
+		Kind:       ErrKindHTTPStatus,
+		StatusCode: statusCode,
+		Err:        fmt.Errorf("unexpected status %d for %q", statusCode, url),
+	}
+}
+
+// Error implements error
+func (e *ScrapeError) Error() string {
+	if e.Err == nil {
+		return string(e.Kind)
+	}
+	return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ScrapeError) Unwrap() error {
+	return e.Err
+}
+
+// AsScrapeError converts any error to the scrape error,
+// the kind is determined based on the error type if it is not a scrape error already
+func AsScrapeError(err error) *ScrapeError {
+	if err == nil {
+		return nil
+	}
+
+	var scrapeErr *ScrapeError
+	if errors.As(err, &scrapeErr) {
+		return scrapeErr
+	}
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr) && !dnsErr.IsTimeout:
+		return NewScrapeError(ErrKindDNS, err)
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return NewScrapeError(ErrKindTimeout, err)
+	default:
+		return NewScrapeError(ErrKindUnknown, err)
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/pestanko/miniscrape/internal/config"
+)
+
+// validateFileURL checks whether the file URL points to the allowed directory
+func validateFileURL(cfg *config.AppConfig, pageURL string) error {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || parsed.Scheme != "file" {
+		return nil
+	}
+
+	return validateLocalFile(cfg, FilePathFromURL(parsed))
+}
+
+// validateLocalFile checks whether the file is in the fixtures root directory
+func validateLocalFile(cfg *config.AppConfig, fp string) error {
+	return CheckFileInRoot(cfg.Fixtures.GetRoot(), fp)
+}
+
+// CheckFileInRoot checks whether the file is in the root directory,
+// the symlinks are resolved, so the link in the root can not point outside of it
+func CheckFileInRoot(root, fp string) error {
+	absRoot, err := resolvePath(root)
+	if err != nil {
+		return err
+	}
+
+	abs, err := resolvePath(fp)
+	if err != nil {
+		return err
+	}
+
+	if rel, err := filepath.Rel(absRoot, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("file %q is not in the fixtures root %q", fp, absRoot)
+	}
+
+	return nil
+}
+
+// resolvePath returns the absolute path with the symlinks resolved,
+// the path of the file which does not exist (yet) is only made absolute
+func resolvePath(fp string) (string, error) {
+	abs, err := filepath.Abs(fp)
+	if err != nil {
+		return "", err
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved, nil
+	}
+
+	return abs, nil
+}
+
+// FilePathFromURL returns the local file path of the file URL,
+// "file:///abs/path" is absolute, "file://rel/path" and "file:rel/path" are relative
+func FilePathFromURL(u *url.URL) string {
+	if u.Opaque != "" {
+		return filepath.FromSlash(u.Opaque)
+	}
+	return filepath.FromSlash(u.Host + u.Path)
+}
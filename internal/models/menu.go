@@ -0,0 +1,42 @@
+package models
+
+// Menu structured representation of the page content
+type Menu struct {
+	// Sections of the menu (soups, main courses, ...)
+	Sections []MenuSection `json:"sections"`
+}
+
+// MenuSection section of the menu
+type MenuSection struct {
+	// Name of the section, empty for dishes before the first section
+	Name string `json:"name"`
+	// Dishes in the section
+	Dishes []Dish `json:"dishes"`
+}
+
+// Dish single item of the menu
+type Dish struct {
+	// Name of the dish
+	Name string `json:"name"`
+	// Price of the dish, 0 if unknown
+	Price float64 `json:"price,omitempty"`
+	// Currency of the price
+	Currency string `json:"currency,omitempty"`
+	// Allergens list of allergen codes
+	Allergens []string `json:"allergens,omitempty"`
+	// Vegetarian whether the dish is vegetarian
+	Vegetarian bool `json:"vegetarian"`
+}
+
+// IsEmpty whether the menu has no dishes
+func (m *Menu) IsEmpty() bool {
+	if m == nil {
+		return true
+	}
+	for _, section := range m.Sections {
+		if len(section.Dishes) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pestanko/miniscrape/internal/config"
 	"go.opentelemetry.io/otel/attribute"
@@ -34,10 +35,22 @@ type Page struct {
 	Homepage string `yaml:"homepage" json:"homepage"`
 	// URL of the page where the lunch menu is
 	URL string `yaml:"url" json:"url"`
+	// Fixture path to the local file with the page content used by the fixture resolver,
+	// relative to the category file directory
+	Fixture string `yaml:"fixture" json:"fixture"`
+	// Encoding of the page content (ex. "windows-1250"), overrides the Content-Type charset
+	// and the detected encoding, empty - determined automatically
+	Encoding string `yaml:"encoding" json:"encoding"`
 	// Query css query to use for element extraction
 	Query string `yaml:"query" json:"query"`
 	// XPath query to use for element extraction
 	XPath string `yaml:"xpath" json:"xpath"`
+	// Exclude selectors of the nodes removed from the extracted elements (ex. cookie banners),
+	// selectors starting with "/", "./" or "(" are XPath, the others are CSS queries
+	Exclude []string `yaml:"exclude" json:"exclude"`
+	// KeepRelativeURLs whether the relative links and image sources of the extracted elements are kept,
+	// by default they are resolved against the page URL (or the <base> of the page)
+	KeepRelativeURLs bool `yaml:"keepRelativeUrls" json:"keepRelativeUrls"`
 	// CachePolicy for the webpage
 	CachePolicy string `yaml:"cache_policy" json:"cachePolicy"`
 	// Resolver to be used
@@ -50,8 +63,31 @@ type Page struct {
 	Tags []string `yaml:"tags" json:"tags"`
 	// Filters for the page
 	Filters FiltersConfig `yaml:"filters" json:"filters"`
+	// Auth configuration of the login step required before the page content is fetched
+	Auth AuthConfig `yaml:"auth" json:"auth"`
+	// Request configuration for the HTTP request to get webpage content
+	Request RequestConfig `yaml:"request" json:"request"`
+	// Fetch configuration overrides for the outbound requests
+	Fetch config.FetchCfg `yaml:"fetch" json:"fetch"`
 	// Command config for cmd to be executed to get webpage content
 	Command CommandsConfig `yaml:"command" json:"command"`
+	// JSON config for the json resolver
+	JSON JSONConfig `yaml:"json" json:"json"`
+	// Feed config for the feed resolver
+	Feed FeedConfig `yaml:"feed" json:"feed"`
+	// Follow config for the follow resolver
+	Follow FollowConfig `yaml:"follow" json:"follow"`
+	// Image config for the image resolver
+	Image ImageConfig `yaml:"image" json:"image"`
+	// Iframe config for the iframe resolver
+	Iframe IframeConfig `yaml:"iframe" json:"iframe"`
+	// Menu config for parsing the structured menu from the content
+	Menu MenuConfig `yaml:"menu" json:"menu"`
+	// TargetDay day the content is resolved for, zero - today, it is set for each run
+	TargetDay time.Time `yaml:"-" json:"-"`
+	// Sources ordered list of alternative sources of the page content,
+	// the first source with non-empty content wins
+	Sources []PageSource `yaml:"sources" json:"sources"`
 }
 
 // Namespace for the page
@@ -59,6 +95,100 @@ func (p Page) Namespace() string {
 	return fmt.Sprintf("%s/%s", p.Category, p.CodeName)
 }
 
+// WithSource returns a copy of the page with the source configuration applied,
+// the source values override the page values, missing values are inherited
+func (p Page) WithSource(src PageSource) Page {
+	p.Sources = nil
+	if src.URL != "" {
+		p.URL = src.URL
+	}
+	if src.Resolver != "" {
+		p.Resolver = src.Resolver
+	}
+	if src.Query != "" || src.XPath != "" {
+		p.Query = src.Query
+		p.XPath = src.XPath
+		p.Exclude = nil
+	}
+	if src.Exclude != nil {
+		p.Exclude = src.Exclude
+	}
+	if src.Filters != nil {
+		p.Filters = *src.Filters
+	}
+	if src.Request != nil {
+		p.Request = *src.Request
+	}
+	if src.JSON != nil {
+		p.JSON = *src.JSON
+	}
+	if src.Feed != nil {
+		p.Feed = *src.Feed
+	}
+	if src.Follow != nil {
+		p.Follow = *src.Follow
+	}
+	if src.Image != nil {
+		p.Image = *src.Image
+	}
+	return p
+}
+
+// PageSource alternative source of the page content
+type PageSource struct {
+	// Name of the source, used to record which source provided the content
+	Name string `yaml:"name" json:"name"`
+	// URL of the source
+	URL string `yaml:"url" json:"url"`
+	// Resolver to be used for the source
+	Resolver string `yaml:"resolver" json:"resolver"`
+	// Query css query to use for element extraction
+	Query string `yaml:"query" json:"query"`
+	// XPath query to use for element extraction
+	XPath string `yaml:"xpath" json:"xpath"`
+	// Exclude selectors of the nodes removed from the extracted elements,
+	// the page exclude selectors are not used when the source has its own query or xpath
+	Exclude []string `yaml:"exclude" json:"exclude"`
+	// Filters for the source
+	Filters *FiltersConfig `yaml:"filters" json:"filters"`
+	// Request configuration for the source
+	Request *RequestConfig `yaml:"request" json:"request"`
+	// JSON config for the json resolver
+	JSON *JSONConfig `yaml:"json" json:"json"`
+	// Feed config for the feed resolver
+	Feed *FeedConfig `yaml:"feed" json:"feed"`
+	// Follow config for the follow resolver
+	Follow *FollowConfig `yaml:"follow" json:"follow"`
+	// Image config for the image resolver
+	Image *ImageConfig `yaml:"image" json:"image"`
+}
+
+// Label returns the name of the source, or its URL if it has no name
+func (s PageSource) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.URL
+}
+
+// RequestConfig HTTP request configuration for the page
+type RequestConfig struct {
+	// Method HTTP method of the request, default GET
+	Method string `yaml:"method" json:"method"`
+	// Headers additional request headers
+	Headers map[string]string `yaml:"headers" json:"headers"`
+	// Query additional query parameters appended to the URL
+	Query map[string]string `yaml:"query" json:"query"`
+	// Cookies to be sent with the request
+	Cookies map[string]string `yaml:"cookies" json:"cookies"`
+	// Form url encoded form body of the request
+	Form map[string]string `yaml:"form" json:"form"`
+	// JSON raw json body of the request
+	JSON string `yaml:"json" json:"json"`
+	// Timeout of the request including reading the body, if empty - the default timeout (30s)
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+}
+
 // CommandsConfig wrapper for command configuration for the page
 type CommandsConfig struct {
 	// Content command configuration content
@@ -71,10 +201,128 @@ type CommandConfig struct {
 	Name string `yaml:"name" json:"name"`
 	// Args of the command
 	Args []string `yaml:"args" json:"args"`
+	// Timeout of the command, if empty - use default timeout
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+	// Env additional environment variables of the command,
+	// only PATH and HOME are inherited from the server environment
+	Env map[string]string `yaml:"env" json:"env"`
+	// Dir working directory of the command
+	Dir string `yaml:"dir" json:"dir"`
+	// Stdin content passed to the standard input of the command
+	Stdin string `yaml:"stdin" json:"stdin"`
+}
+
+// JSONConfig configuration of the json resolver
+type JSONConfig struct {
+	// Path gjson path expression to select the values from the response
+	// see: https://github.com/tidwall/gjson/blob/master/SYNTAX.md
+	Path string `yaml:"path" json:"path"`
+	// Fields to be rendered for the selected objects (in order), if empty - render all
+	Fields []string `yaml:"fields" json:"fields"`
+	// HTML whether the selected string values contain HTML to be converted to markdown
+	HTML bool `yaml:"html" json:"html"`
+}
+
+// FeedConfig configuration of the feed (RSS/Atom) resolver
+type FeedConfig struct {
+	// Days select only the entries published in the last number of days, 0 - no limit
+	Days int `yaml:"days" json:"days"`
+	// Title regular expression the entry title has to match
+	Title string `yaml:"title" json:"title"`
+	// Limit maximal number of entries, 0 - no limit
+	Limit int `yaml:"limit" json:"limit"`
+}
+
+// FollowConfig configuration of the follow resolver, it finds the link on the page
+// (in one or more hops) and resolves the target page using another resolver
+type FollowConfig struct {
+	// Hops list of the link selection steps, applied in order
+	Hops []FollowHop `yaml:"hops" json:"hops"`
+	// Resolver used to resolve the target page, default "default"
+	Resolver string `yaml:"resolver" json:"resolver"`
+}
+
+// FollowHop single link selection step
+type FollowHop struct {
+	// Query css query to select the link elements
+	Query string `yaml:"query" json:"query"`
+	// XPath query to select the link elements
+	XPath string `yaml:"xpath" json:"xpath"`
+	// Pattern regular expression the link href or text has to match, if empty - use the first link
+	Pattern string `yaml:"pattern" json:"pattern"`
+}
+
+// ImageConfig configuration of the image resolver
+type ImageConfig struct {
+	// Limit maximal number of images, 0 - no limit
+	Limit int `yaml:"limit" json:"limit"`
+	// Store whether the images should be downloaded to the cache
+	// and served by the API instead of the original URLs
+	Store bool `yaml:"store" json:"store"`
+}
+
+// AuthConfig configuration of the login step, the session cookies are kept in memory
+// for the login host and reused until the session expires or the process exits,
+// every run of the scrape command and every restart of the server logs in again
+type AuthConfig struct {
+	// URL where the login form is submitted
+	URL string `yaml:"url" json:"url"`
+	// Method of the login request, default "POST"
+	Method string `yaml:"method" json:"method"`
+	// UsernameField name of the form field with the username
+	UsernameField string `yaml:"usernameField" json:"usernameField"`
+	// PasswordField name of the form field with the password
+	PasswordField string `yaml:"passwordField" json:"passwordField"`
+	// UsernameEnv name of the environment variable with the username
+	UsernameEnv string `yaml:"usernameEnv" json:"usernameEnv"`
+	// PasswordEnv name of the environment variable with the password
+	PasswordEnv string `yaml:"passwordEnv" json:"passwordEnv"`
+	// Fields additional form fields sent with the credentials
+	Fields map[string]string `yaml:"fields" json:"fields"`
+	// Expired regular expression matched against the page content,
+	// a match means the session has expired (ex. the login form is shown instead of the menu),
+	// the session has also expired on 401/403 responses and on the redirect to the login URL
+	Expired string `yaml:"expired" json:"expired"`
+}
+
+// IsEnabled whether the page requires the login
+func (c AuthConfig) IsEnabled() bool {
+	return c.URL != ""
+}
+
+// IframeConfig configuration of the iframe resolver, it locates the iframe on the page
+// and applies the page query and filters to the embedded document
+type IframeConfig struct {
+	// Query css query to locate the iframe, default "iframe"
+	Query string `yaml:"query" json:"query"`
+	// XPath query to locate the iframe
+	XPath string `yaml:"xpath" json:"xpath"`
+	// URLOnly whether only the page URL should be returned to be embedded by the client,
+	// the embedded document is not scraped
+	URLOnly bool `yaml:"urlOnly" json:"urlOnly"`
+}
+
+// MenuConfig configuration of the structured menu parsing,
+// patterns are regular expressions matched against each line of the filtered content
+type MenuConfig struct {
+	// Enabled whether the menu should be parsed from the content
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Sections patterns of the section headings, the whole line is the section name
+	Sections []string `yaml:"sections" json:"sections"`
+	// Dish pattern of the dish line with named groups:
+	// name (required), price, currency and allergens
+	Dish string `yaml:"dish" json:"dish"`
+	// Vegetarian pattern marking the dish as vegetarian
+	Vegetarian string `yaml:"vegetarian" json:"vegetarian"`
+	// Currency used when the dish line has no currency
+	Currency string `yaml:"currency" json:"currency"`
 }
 
-// FiltersConfig for the webpage
+// FiltersConfig for the webpage, the filters are applied in the default order
+// (newline, cut, day, cutLine, regex), unless the pipeline is declared
 type FiltersConfig struct {
+	// Pipeline ordered list of the filter steps, it replaces the other filters configuration
+	Pipeline []FilterStep `yaml:"pipeline"`
 	// Cut filter configuration
 	Cut CutFilter `yaml:"cut"`
 	// CutLine filter configuration
@@ -83,14 +331,96 @@ type FiltersConfig struct {
 	Day DayFilter `yaml:"day"`
 	// HTML filter configuration
 	HTML HTMLFilter `yaml:"html"`
+	// Regex filter configuration
+	Regex RegexFilter `yaml:"regex"`
+}
+
+// UsesDay whether the content depends on the current day
+func (c *FiltersConfig) UsesDay() bool {
+	if len(c.Pipeline) == 0 {
+		return c.Day.Enabled
+	}
+
+	for _, step := range c.Pipeline {
+		if step.Type == FilterStepDay {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter step types of the filters pipeline
+const (
+	FilterStepNewLine = "newline"
+	FilterStepCut     = "cut"
+	FilterStepDay     = "day"
+	FilterStepCutLine = "cutLine"
+	FilterStepRegex   = "regex"
+)
+
+// FilterStep single step of the filters pipeline, the parameters are
+// the same as of the filter configuration of the step type
+type FilterStep struct {
+	// Type of the filter - newline, cut, day, cutLine or regex
+	Type          string `yaml:"type"`
+	CutFilter     `yaml:",inline"`
+	CutLineFilter `yaml:",inline"`
+	DayFilter     `yaml:",inline"`
+	RegexFilter   `yaml:",inline"`
 }
 
+// HTML conversion modes
+const (
+	// HTMLModeMarkdown converts the HTML to markdown (default)
+	HTMLModeMarkdown = "markdown"
+	// HTMLModeText converts the HTML to plain text
+	HTMLModeText = "text"
+	// HTMLModeHTML keeps the sanitised HTML
+	HTMLModeHTML = "html"
+)
+
+// HTML tables conversions
+const (
+	// HTMLTablesPretty renders the tables as text tables (text mode)
+	HTMLTablesPretty = "pretty"
+	// HTMLTablesCustom renders each table row as a paragraph
+	HTMLTablesCustom = "custom"
+	// HTMLTablesMarkdown renders the tables as markdown tables (markdown mode)
+	HTMLTablesMarkdown = "markdown"
+	// HTMLTablesHTML keeps the tables as HTML (markdown mode)
+	HTMLTablesHTML = "html"
+)
+
 // HTMLFilter for the webpage
 type HTMLFilter struct {
-	// TextOnly - whether it should parse only the text
+	// Mode of the conversion - markdown (default), text or html
+	Mode string `yaml:"mode"`
+	// TextOnly - whether it should parse only the text (text mode)
 	TextOnly bool `yaml:"textOnly"`
-	// Tables resolver
+	// Tables resolver - text mode: custom (default) or pretty,
+	// markdown mode: markdown (default), custom or html, used only when the mode is set
 	Tables string `yaml:"tables"`
+	// DropImages whether the images are removed (markdown mode)
+	DropImages bool `yaml:"dropImages"`
+	// DropLinks whether the links are replaced by their text (markdown mode)
+	DropLinks bool `yaml:"dropLinks"`
+}
+
+// GetMode returns the conversion mode, markdown if not set
+func (f *HTMLFilter) GetMode() string {
+	if f.Mode == "" {
+		return HTMLModeMarkdown
+	}
+	return f.Mode
+}
+
+// GetMarkdownTables returns the tables conversion of the markdown mode, the tables are used
+// only when the mode is set, the pages without the mode configured the tables for the text conversion
+func (f *HTMLFilter) GetMarkdownTables() string {
+	if f.Mode == "" || f.Tables == "" {
+		return HTMLTablesMarkdown
+	}
+	return f.Tables
 }
 
 // CutFilter for the webpage
@@ -113,16 +443,40 @@ type CutLineFilter struct {
 	MinLen int `yaml:"minLen"`
 }
 
-// DayFilter for the webpage
+// RegexFilter for the webpage, the extraction is applied before the rules
+type RegexFilter struct {
+	// Extract regular expression, only its matches are kept (one per line),
+	// if it has named groups, only the named groups are kept (joined by space)
+	Extract string `yaml:"extract"`
+	// Rules find/replace rules applied in order
+	Rules []RegexRule `yaml:"rules"`
+}
+
+// RegexRule single find/replace rule of the regex filter
+type RegexRule struct {
+	// Find regular expression to be replaced
+	Find string `yaml:"find"`
+	// Replace replacement of the matches, it can reference the groups ($1, ${name})
+	Replace string `yaml:"replace"`
+}
+
+// DayFilter for the webpage, the content is cut between the heading of the target day
+// and the heading of the next day, headings are the day names or the dates (ex. "18. 10.")
 type DayFilter struct {
 	// List of days to be used as separators, if empty - use default
 	Days []string `yaml:"days"`
+	// Locales names of the configured day names sets to be used, if empty - use all
+	Locales []string `yaml:"locales"`
 	// Whether the filter is enabled
 	Enabled bool `yaml:"enabled"`
 }
 
+// PageValidator checks whether the loaded page is valid,
+// invalid pages are skipped
+type PageValidator func(page *Page) error
+
 // LoadCategories Load all categories from the app config
-func LoadCategories(ctx context.Context, cfg *config.AppConfig) []Category {
+func LoadCategories(ctx context.Context, cfg *config.AppConfig, validators ...PageValidator) []Category {
 	baseDir := "config/categories"
 	var categories []Category
 
@@ -135,7 +489,7 @@ func LoadCategories(ctx context.Context, cfg *config.AppConfig) []Category {
 	}()
 
 	for _, catName := range cfg.Categories {
-		ok, cat := loadCategoryFile(ctx, baseDir, catName)
+		ok, cat := loadCategoryFile(ctx, cfg, baseDir, catName, validators)
 		ll := log.With().Str("category", cat.Name).Logger()
 		if ok {
 			ll.Info().
@@ -157,7 +511,13 @@ func LoadCategories(ctx context.Context, cfg *config.AppConfig) []Category {
 	return categories
 }
 
-func loadCategoryFile(ctx context.Context, baseDir string, catName string) (bool, Category) {
+func loadCategoryFile(
+	ctx context.Context,
+	cfg *config.AppConfig,
+	baseDir string,
+	catName string,
+	validators []PageValidator,
+) (bool, Category) {
 	fp := filepath.Join(baseDir, catName+".yml")
 	span := trace.SpanFromContext(ctx)
 	span.AddEvent("start load category file")
@@ -188,18 +548,71 @@ func loadCategoryFile(ctx context.Context, baseDir string, catName string) (bool
 	}
 
 	// Normalize the pages
-	for idx := range cat.Pages {
-		if cat.Pages[idx].Category == "" {
-			cat.Pages[idx].Category = cat.Name
+	pages := make([]Page, 0, len(cat.Pages))
+	for _, page := range cat.Pages {
+		if page.Category == "" {
+			page.Category = cat.Name
+		}
+		if page.Resolver == "" {
+			page.Resolver = "default"
+		}
+		page.Fetch = cfg.Fetch.Override(page.Fetch)
+		if page.Fixture != "" && !filepath.IsAbs(page.Fixture) {
+			page.Fixture = filepath.Join(baseDir, page.Fixture)
 		}
-		if cat.Pages[idx].Resolver == "" {
-			cat.Pages[idx].Resolver = "default"
+
+		if err := validatePage(cfg, &page, validators); err != nil {
+			log.Error().
+				Err(err).
+				Str("file", fp).
+				Str("page", page.CodeName).
+				Msg("Invalid page - skipping")
+			continue
 		}
+
+		pages = append(pages, page)
 	}
+	cat.Pages = pages
 
 	return true, cat
 }
 
+// validatePage checks whether the page can be scraped with the application configuration
+func validatePage(cfg *config.AppConfig, page *Page, validators []PageValidator) error {
+	cmdName := page.Command.Content.Name
+	if cmdName != "" && !cfg.Commands.IsAllowed(cmdName) {
+		return fmt.Errorf("command %q is not allowed", cmdName)
+	}
+
+	for _, pageURL := range page.urls() {
+		if err := validateFileURL(cfg, pageURL); err != nil {
+			return err
+		}
+	}
+	if page.Fixture != "" {
+		if err := validateLocalFile(cfg, page.Fixture); err != nil {
+			return err
+		}
+	}
+
+	for _, validator := range validators {
+		if err := validator(page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// urls returns the URLs of the page and all its sources
+func (p *Page) urls() []string {
+	urls := []string{p.URL}
+	for _, src := range p.Sources {
+		urls = append(urls, src.URL)
+	}
+	return urls
+}
+
 // RunSelector represents which pages should be selected
 type RunSelector struct {
 	// Tags list of all tags to be selected
@@ -210,4 +623,6 @@ type RunSelector struct {
 	Page string
 	// Force load even if disabled
 	Force bool
+	// Day target day of the content, zero - today
+	Day time.Time
 }
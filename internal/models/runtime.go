@@ -22,4 +22,23 @@ type RunResult struct {
 	Status RunResultStatus
 	// Kind of the result
 	Kind string
+	// Error why the page failed, nil on success
+	Error *ScrapeError
+	// Menu structured menu, nil if not available
+	Menu *Menu
+	// Source label of the page source that provided the content,
+	// empty if the page has no alternative sources
+	Source string
+	// Resolver of the page source that provided the content,
+	// empty if the page has no alternative sources
+	Resolver string
+}
+
+// ResolverName returns the resolver which provided the content,
+// the resolver of the page source if the page has alternative sources
+func (r *RunResult) ResolverName() string {
+	if r.Resolver != "" {
+		return r.Resolver
+	}
+	return r.Page.Resolver
 }
@@ -1,21 +1,126 @@
 package filters
 
 import (
-	"github.com/pestanko/miniscrape/internal/models"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
 )
 
+// builtinDayLocales day names (starting with Monday) available without any configuration
+var builtinDayLocales = map[string][]string{
+	"cs":       {"Pondělí", "Úterý", "Středa", "Čtvrtek", "Pátek", "Sobota", "Neděle"},
+	"cs_ascii": {"Pondeli", "Uteri", "Streda", "Ctvrtek", "Patek", "Sobota", "Nedele"},
+	"en":       {"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"},
+}
+
+// builtinDayLocalesOrder order in which the built-in locales are tried
+var builtinDayLocalesOrder = []string{"cs", "cs_ascii", "en"}
+
+// datePattern numeric date headings (ex. "18. 10.", "Po 18.10.", "18.10.2026")
+var datePattern = regexp.MustCompile(`(?:^|[^\d.])(\d{1,2})\.\s?(\d{1,2})\.(?:\s?(\d{4}))?`)
+
+// dayConfig configured locales and timezone of the day filter
+type dayConfig struct {
+	mu       sync.RWMutex
+	locales  map[string][]string
+	order    []string
+	location *time.Location
+}
+
+var defaultDayConfig = &dayConfig{
+	locales:  builtinDayLocales,
+	order:    builtinDayLocalesOrder,
+	location: time.Local,
+}
+
+// ConfigureDay sets the day names locales and the timezone of the day filter
+func ConfigureDay(cfg config.DayCfg) error {
+	loc, err := cfg.Location()
+	if err != nil {
+		return fmt.Errorf("invalid day timezone: %w", err)
+	}
+
+	locales := make(map[string][]string, len(builtinDayLocales)+len(cfg.Locales))
+	order := append([]string{}, builtinDayLocalesOrder...)
+	for name, days := range builtinDayLocales {
+		locales[name] = days
+	}
+
+	var names []string
+	for name := range cfg.Locales {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		days := cfg.Locales[name]
+		if len(days) != 7 {
+			return fmt.Errorf("day locale %q has to have 7 days, has %d", name, len(days))
+		}
+		if _, ok := locales[name]; !ok {
+			order = append(order, name)
+		}
+		locales[name] = days
+	}
+
+	defaultDayConfig.mu.Lock()
+	defer defaultDayConfig.mu.Unlock()
+
+	defaultDayConfig.locales = locales
+	defaultDayConfig.order = order
+	defaultDayConfig.location = loc
+
+	return nil
+}
+
+// daySets returns the day names sets of the locales, all locales if none is provided
+func (c *dayConfig) daySets(locales []string) ([][]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(locales) == 0 {
+		locales = c.order
+	}
+
+	result := make([][]string, 0, len(locales))
+	for _, name := range locales {
+		days, ok := c.locales[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown day locale %q", name)
+		}
+		result = append(result, days)
+	}
+
+	return result, nil
+}
+
+// today returns the current day in the configured timezone
+func (c *dayConfig) today() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return time.Now().In(c.location)
+}
+
 // NewDayFilter a new instance of the filter that
 // cuts a content based on days
 func NewDayFilter(page *models.Page) PageFilter {
 	return &dayFilter{
-		page.Filters.Day,
+		day:    page.Filters.Day,
+		target: page.TargetDay,
 	}
 }
 
 type dayFilter struct {
-	day models.DayFilter
+	day    models.DayFilter
+	target time.Time
 }
 
 func (f *dayFilter) IsEnabled() bool {
@@ -30,41 +135,154 @@ func (f *dayFilter) config() *models.DayFilter {
 	return &f.day
 }
 
+func (f *dayFilter) targetDay() time.Time {
+	if f.target.IsZero() {
+		return defaultDayConfig.today()
+	}
+	return f.target
+}
+
 func (f *dayFilter) Filter(content string) (string, error) {
-	days := f.config().Days
-	weekday := time.Now().Weekday()
+	target := f.targetDay()
 	upperContent := strings.ToUpper(content)
-	if len(days) != 0 {
-		start, end := tryApplyDayFilter(upperContent, days, weekday)
-		return cutContent(content, start, end), nil
-	}
-	allVersions := [][]string{
-		{"Pondělí", "Úterý", "Středa", "Čtvrtek", "Pátek", "Sobota", "Neděle"},
-		{"Pondeli", "Uteri", "Streda", "Ctvrtek", "Patek", "Sobota", "Nedele"},
-		{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"},
-	}
-	for _, days := range allVersions {
-		start, end := tryApplyDayFilter(upperContent, days, weekday)
-		if start == -1 && end == -1 {
-			continue
+
+	daySets := [][]string{f.config().Days}
+	if len(f.config().Days) == 0 {
+		var err error
+		if daySets, err = defaultDayConfig.daySets(f.config().Locales); err != nil {
+			return content, err
+		}
+	}
+
+	// the dates are more precise than the weekday names, the content for several weeks
+	// or the past days of the week could contain the same weekday names
+	if headings := findDateHeadings(content, target); len(headings) != 0 {
+		if start, end, ok := findDateBoundaries(content, headings); ok {
+			return content[start:end], nil
 		}
-		return cutContent(content, start, end), nil
+		return content, nil
 	}
+
+	for _, days := range daySets {
+		if start, end, ok := findDayBoundaries(upperContent, days, target.Weekday()); ok {
+			return content[start:end], nil
+		}
+	}
+
 	return content, nil
 }
 
-func tryApplyDayFilter(content string, days []string, weekday time.Weekday) (int, int) {
-	currIdx := (int(weekday) - 1) % 7
-	if currIdx < 0 {
-		currIdx = 6
+// findDayBoundaries finds the heading of the weekday and the heading of the next day,
+// if there is no next day, the end of the content is used
+func findDayBoundaries(content string, days []string, weekday time.Weekday) (int, int, bool) {
+	if len(days) != 7 {
+		return 0, 0, false
 	}
+
+	currIdx := (int(weekday) + 6) % 7
 	nextIdx := (currIdx + 1) % 7
-	var upperDays []string
-	for _, day := range days {
-		upperDays = append(upperDays, strings.ToUpper(day))
+	currDay := strings.ToUpper(days[currIdx])
+	nextDay := strings.ToUpper(days[nextIdx])
+
+	start := strings.Index(content, currDay)
+	if start == -1 {
+		return 0, 0, false
+	}
+
+	end := strings.Index(content[start+len(currDay):], nextDay)
+	if end == -1 {
+		return start, len(content), true
+	}
+
+	return start, start + len(currDay) + end, true
+}
+
+// dateHeading line of the content with the date heading
+type dateHeading struct {
+	line     int
+	isTarget bool
+}
+
+// findDateHeadings finds the lines with the date headings, lines with more dates
+// (ex. "19.10. - 23.10.") are ranges, not headings, so they are skipped
+func findDateHeadings(content string, target time.Time) []dateHeading {
+	type dateLine struct {
+		dateHeading
+		count int
+	}
+
+	var lines []dateLine
+	for _, match := range datePattern.FindAllStringSubmatchIndex(content, -1) {
+		line := lineStart(content, match[2])
+		if len(lines) != 0 && lines[len(lines)-1].line == line {
+			lines[len(lines)-1].count++
+			continue
+		}
+		lines = append(lines, dateLine{
+			dateHeading: dateHeading{line: line, isTarget: isTargetDate(content, match, target)},
+			count:       1,
+		})
+	}
+
+	var headings []dateHeading
+	for _, l := range lines {
+		if l.count == 1 {
+			headings = append(headings, l.dateHeading)
+		}
+	}
+
+	return headings
+}
+
+// findDateBoundaries finds the date heading of the target day and the next date heading,
+// if there is no next date heading, the end of the content is used
+func findDateBoundaries(content string, headings []dateHeading) (int, int, bool) {
+	start := -1
+	for _, h := range headings {
+		if start == -1 && h.isTarget {
+			start = h.line
+			continue
+		}
+		if start != -1 && !h.isTarget {
+			return start, h.line, true
+		}
+	}
+
+	if start == -1 {
+		return 0, 0, false
+	}
+
+	return start, len(content), true
+}
+
+// isTargetDate whether the date pattern match is the target day
+func isTargetDate(content string, match []int, target time.Time) bool {
+	day, _ := strconv.Atoi(content[match[2]:match[3]])
+	month, _ := strconv.Atoi(content[match[4]:match[5]])
+	if day != target.Day() || month != int(target.Month()) {
+		return false
+	}
+
+	if match[6] == -1 {
+		return true
+	}
+
+	year, _ := strconv.Atoi(content[match[6]:match[7]])
+	return year == target.Year()
+}
+
+func lineStart(content string, idx int) int {
+	return strings.LastIndex(content[:idx], "\n") + 1
+}
+
+func validateDayFilter(cfg *models.DayFilter) error {
+	if len(cfg.Days) != 0 && len(cfg.Days) != 7 {
+		return fmt.Errorf("day filter: days has to have 7 days, has %d", len(cfg.Days))
+	}
+
+	if _, err := defaultDayConfig.daySets(cfg.Locales); err != nil {
+		return fmt.Errorf("day filter: %w", err)
 	}
-	currDay := upperDays[currIdx]
-	nextDay := upperDays[nextIdx]
 
-	return findBoundaries(content, currDay, nextDay)
+	return nil
 }
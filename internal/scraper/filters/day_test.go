@@ -0,0 +1,102 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func applyDayFilter(t *testing.T, day models.DayFilter, target time.Time, content string) string {
+	day.Enabled = true
+	result, err := NewDayFilter(&models.Page{
+		Filters:   models.FiltersConfig{Day: day},
+		TargetDay: target,
+	}).Filter(content)
+	assert.NoError(t, err)
+	return result
+}
+
+func TestDayFilterWeekdayNames(t *testing.T) {
+	assert := assert.New(t)
+
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	content := "Weekly menu\nPondeli\nSoup\nUteri\nGoulash\nStreda\nSteak"
+
+	assert.Equal("Pondeli\nSoup\n", applyDayFilter(t, models.DayFilter{}, monday, content))
+	assert.Equal("Uteri\nGoulash\n", applyDayFilter(t, models.DayFilter{}, monday.AddDate(0, 0, 1), content))
+	assert.Equal("Streda\nSteak", applyDayFilter(t, models.DayFilter{}, monday.AddDate(0, 0, 2), content))
+	assert.Equal(content, applyDayFilter(t, models.DayFilter{Locales: []string{"en"}}, monday, content))
+}
+
+func TestDayFilterDateHeadings(t *testing.T) {
+	assert := assert.New(t)
+
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	content := "Menu 19.10. - 23.10.\nPo 19. 10.\nSoup 45.50\nÚt 20.10.2026\nGoulash\nSt 21.10.\nSteak"
+
+	assert.Equal("Po 19. 10.\nSoup 45.50\n", applyDayFilter(t, models.DayFilter{}, monday, content))
+	assert.Equal("Út 20.10.2026\nGoulash\n", applyDayFilter(t, models.DayFilter{}, monday.AddDate(0, 0, 1), content))
+	assert.Equal("St 21.10.\nSteak", applyDayFilter(t, models.DayFilter{}, monday.AddDate(0, 0, 2), content))
+	assert.Equal(content, applyDayFilter(t, models.DayFilter{}, monday.AddDate(1, 0, 1), content))
+}
+
+func TestDayFilterDatesBeforeWeekdayNames(t *testing.T) {
+	assert := assert.New(t)
+
+	thursday := time.Date(2026, 10, 29, 0, 0, 0, 0, time.UTC)
+	content := "Čtvrtek 22.10.\nSoup\nPátek 23.10.\nGoulash\nČtvrtek 29.10.\nSteak\nPátek 30.10.\nPasta"
+
+	assert.Equal("Čtvrtek 29.10.\nSteak\n", applyDayFilter(t, models.DayFilter{}, thursday, content))
+	assert.Equal("Čtvrtek 22.10.\nSoup\n", applyDayFilter(t, models.DayFilter{}, thursday.AddDate(0, 0, -7), content))
+	assert.Equal(content, applyDayFilter(t, models.DayFilter{}, thursday.AddDate(0, 0, 7), content))
+}
+
+func TestConfigureDayLocales(t *testing.T) {
+	assert := assert.New(t)
+
+	defer func() {
+		assert.NoError(ConfigureDay(config.DayCfg{}))
+	}()
+
+	assert.Error(ConfigureDay(config.DayCfg{Locales: map[string][]string{"de": {"Montag"}}}))
+	assert.Error(ConfigureDay(config.DayCfg{Timezone: "Mars/Olympus"}))
+	assert.NoError(ConfigureDay(config.DayCfg{
+		Timezone: "Europe/Prague",
+		Locales: map[string][]string{
+			"de": {"Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag", "Sonntag"},
+		},
+	}))
+
+	tuesday := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	content := "Montag\nSuppe\nDienstag\nGulasch\nMittwoch\nSteak"
+
+	assert.Equal("Dienstag\nGulasch\n", applyDayFilter(t, models.DayFilter{Locales: []string{"de"}}, tuesday, content))
+	assert.NoError(validateDayFilter(&models.DayFilter{Locales: []string{"de", "cs"}}))
+	assert.Error(validateDayFilter(&models.DayFilter{Locales: []string{"fr"}}))
+}
+
+func TestParseTargetDay(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := config.DayCfg{Timezone: "Europe/Prague"}
+	// 23:30 UTC is already the next day in Prague
+	now := time.Date(2026, 10, 18, 23, 30, 0, 0, time.UTC)
+
+	for value, want := range map[string]string{
+		"":           "2026-10-19",
+		"today":      "2026-10-19",
+		"Tomorrow":   "2026-10-20",
+		"yesterday":  "2026-10-18",
+		"2026-12-24": "2026-12-24",
+	} {
+		day, err := cfg.ParseTargetDay(value, now)
+		assert.NoError(err, value)
+		assert.Equal(want, day.Format(time.DateOnly), value)
+	}
+
+	_, err := cfg.ParseTargetDay("next week", now)
+	assert.Error(err)
+}
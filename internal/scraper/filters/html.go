@@ -0,0 +1,46 @@
+package filters
+
+import (
+	"fmt"
+
+	"github.com/pestanko/miniscrape/internal/models"
+)
+
+// NewHTMLModeConverter a new instance of the filter that
+// converts html based on the configured conversion mode
+func NewHTMLModeConverter(page *models.Page) PageFilter {
+	switch page.Filters.HTML.GetMode() {
+	case models.HTMLModeText:
+		return NewHTMLConverter(page)
+	case models.HTMLModeHTML:
+		return NewHTMLSanitizer(page)
+	default:
+		return NewHTMLToMdConverter(page)
+	}
+}
+
+func validateHTMLFilter(cfg *models.HTMLFilter) error {
+	switch cfg.GetMode() {
+	case models.HTMLModeMarkdown:
+		if cfg.Mode == "" {
+			// the tables are used only when the markdown mode is set
+			break
+		}
+		switch cfg.Tables {
+		case "", models.HTMLTablesMarkdown, models.HTMLTablesCustom, models.HTMLTablesHTML:
+		default:
+			return fmt.Errorf("html filter: unknown tables %q for the markdown mode", cfg.Tables)
+		}
+	case models.HTMLModeText:
+		switch cfg.Tables {
+		case "", models.HTMLTablesCustom, models.HTMLTablesPretty:
+		default:
+			return fmt.Errorf("html filter: unknown tables %q for the text mode", cfg.Tables)
+		}
+	case models.HTMLModeHTML:
+	default:
+		return fmt.Errorf("html filter: unknown mode %q", cfg.Mode)
+	}
+
+	return nil
+}
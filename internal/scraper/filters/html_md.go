@@ -1,8 +1,11 @@
 package filters
 
 import (
+	"strings"
+
 	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/JohannesKaufmann/html-to-markdown/plugin"
+	"github.com/PuerkitoBio/goquery"
 	"github.com/pestanko/miniscrape/internal/models"
 )
 
@@ -19,8 +22,8 @@ type htmlToMdConverter struct {
 }
 
 // Filter implements PageFilter
-func (*htmlToMdConverter) Filter(content string) (string, error) {
-	converter := makeMdConverter()
+func (f *htmlToMdConverter) Filter(content string) (string, error) {
+	converter := makeMdConverter(&f.html)
 
 	return converter.ConvertString(content)
 }
@@ -35,10 +38,71 @@ func (*htmlToMdConverter) Name() string {
 	return "html2md"
 }
 
-func makeMdConverter() *md.Converter {
+func makeMdConverter(cfg *models.HTMLFilter) *md.Converter {
 	converter := md.NewConverter("", true, nil)
 	// Use the `GitHubFlavored` plugin from the `plugin` package.
 	converter.Use(plugin.GitHubFlavored())
 
+	if cfg == nil {
+		return converter
+	}
+
+	// the rules added later take precedence over the built-in ones
+	if cfg.DropImages {
+		converter.AddRules(md.Rule{
+			Filter: []string{"img", "picture"},
+			Replacement: func(_ string, _ *goquery.Selection, _ *md.Options) *string {
+				return md.String("")
+			},
+		})
+	}
+	if cfg.DropLinks {
+		converter.AddRules(md.Rule{
+			Filter: []string{"a"},
+			Replacement: func(content string, _ *goquery.Selection, _ *md.Options) *string {
+				return md.String(content)
+			},
+		})
+	}
+	switch cfg.GetMarkdownTables() {
+	case models.HTMLTablesCustom:
+		converter.AddRules(customTablesRules()...)
+	case models.HTMLTablesHTML:
+		converter.AddRules(md.Rule{
+			Filter: []string{"table"},
+			Replacement: func(_ string, selec *goquery.Selection, _ *md.Options) *string {
+				table, err := goquery.OuterHtml(selec)
+				if err != nil {
+					return nil
+				}
+				return md.String("\n\n" + table + "\n\n")
+			},
+		})
+	}
+
 	return converter
 }
+
+// customTablesRules renders each table row as a paragraph with the cells separated by a space
+func customTablesRules() []md.Rule {
+	return []md.Rule{
+		{
+			Filter: []string{"table", "thead", "tbody", "tfoot"},
+			Replacement: func(content string, _ *goquery.Selection, _ *md.Options) *string {
+				return md.String("\n\n" + strings.TrimSpace(content) + "\n\n")
+			},
+		},
+		{
+			Filter: []string{"tr"},
+			Replacement: func(content string, _ *goquery.Selection, _ *md.Options) *string {
+				return md.String(strings.TrimSpace(content) + "\n\n")
+			},
+		},
+		{
+			Filter: []string{"td", "th"},
+			Replacement: func(content string, _ *goquery.Selection, _ *md.Options) *string {
+				return md.String(strings.TrimSpace(content) + " ")
+			},
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package filters
+
+import (
+	"strings"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// sanitizeAllowedAttrs allowed attributes of the elements, the other attributes are dropped
+var sanitizeAllowedAttrs = map[atom.Atom][]string{
+	atom.A:   {"href", "title"},
+	atom.Img: {"src", "alt", "title"},
+	atom.Td:  {"colspan", "rowspan"},
+	atom.Th:  {"colspan", "rowspan"},
+}
+
+// sanitizeAllowedTags elements kept in the output, the other elements are replaced by their content
+var sanitizeAllowedTags = map[atom.Atom]bool{
+	atom.A: true, atom.B: true, atom.Blockquote: true, atom.Br: true, atom.Caption: true,
+	atom.Code: true, atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true,
+	atom.Em: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Hr: true, atom.I: true, atom.Img: true,
+	atom.Li: true, atom.Ol: true, atom.P: true, atom.Pre: true, atom.S: true,
+	atom.Small: true, atom.Span: true, atom.Strong: true, atom.Sub: true, atom.Sup: true,
+	atom.Table: true, atom.Tbody: true, atom.Td: true, atom.Tfoot: true, atom.Th: true,
+	atom.Thead: true, atom.Tr: true, atom.U: true, atom.Ul: true,
+}
+
+// sanitizeDroppedTags elements removed together with their content
+var sanitizeDroppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Iframe: true,
+	atom.Object: true, atom.Embed: true, atom.Form: true, atom.Svg: true,
+	atom.Template: true, atom.Head: true,
+}
+
+// NewHTMLSanitizer a new instance of the filter that
+// keeps the html, only the allowed elements and attributes are preserved
+func NewHTMLSanitizer(_ *models.Page) PageFilter {
+	return &htmlSanitizer{}
+}
+
+type htmlSanitizer struct{}
+
+// Filter implements PageFilter
+func (*htmlSanitizer) Filter(content string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, node := range nodes {
+		writeSanitizedNode(&sb, node)
+	}
+
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// IsEnabled implements PageFilter
+func (*htmlSanitizer) IsEnabled() bool {
+	return true
+}
+
+// Name implements PageFilter
+func (*htmlSanitizer) Name() string {
+	return "sanitize_html"
+}
+
+func writeSanitizedNode(sb *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		sb.WriteString(html.EscapeString(node.Data))
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		writeSanitizedChildren(sb, node)
+		return
+	default:
+		return
+	}
+
+	if sanitizeDroppedTags[node.DataAtom] {
+		return
+	}
+
+	if !sanitizeAllowedTags[node.DataAtom] {
+		writeSanitizedChildren(sb, node)
+		return
+	}
+
+	sb.WriteString("<" + node.Data)
+	for _, attr := range node.Attr {
+		if !isSanitizeAllowedAttr(node.DataAtom, attr) {
+			continue
+		}
+		sb.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	sb.WriteString(">")
+
+	if node.DataAtom == atom.Br || node.DataAtom == atom.Hr || node.DataAtom == atom.Img {
+		return
+	}
+
+	writeSanitizedChildren(sb, node)
+	sb.WriteString("</" + node.Data + ">")
+}
+
+func writeSanitizedChildren(sb *strings.Builder, node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeSanitizedNode(sb, child)
+	}
+}
+
+func isSanitizeAllowedAttr(tag atom.Atom, attr html.Attribute) bool {
+	if attr.Namespace != "" {
+		return false
+	}
+
+	allowed := false
+	for _, key := range sanitizeAllowedAttrs[tag] {
+		if key == attr.Key {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return false
+	}
+
+	if attr.Key == "href" || attr.Key == "src" {
+		return isSafeURL(attr.Val)
+	}
+
+	return true
+}
+
+// isSafeURL whether the URL is relative or uses the http(s) or mailto scheme
+func isSafeURL(value string) bool {
+	// browsers ignore the whitespace and control characters in the scheme (ex. "java\tscript:")
+	value = strings.ToLower(strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, value))
+	idx := strings.IndexAny(value, ":/?#")
+	if idx == -1 || value[idx] != ':' {
+		return true
+	}
+
+	switch value[:idx] {
+	case "http", "https", "mailto":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLModeConverter(t *testing.T) {
+	assert := assert.New(t)
+
+	content := `<h2>Menu</h2><p>Soup <img src="/soup.png" alt="soup"> <a href="/soup">detail</a></p>` +
+		`<table><tr><td>Goulash</td><td>159</td></tr></table>` +
+		`<script>alert(1)</script><a href="javascript:alert(1)" onclick="x()">bad</a>`
+
+	tests := []struct {
+		name     string
+		html     models.HTMLFilter
+		contains []string
+		missing  []string
+	}{
+		{
+			name:     "markdown",
+			html:     models.HTMLFilter{},
+			contains: []string{"## Menu", "![soup](/soup.png)", "[detail](/soup)", "| Goulash | 159 |"},
+		},
+		{
+			name: "markdown without images and links, tables kept",
+			html: models.HTMLFilter{
+				Mode:       models.HTMLModeMarkdown,
+				DropImages: true,
+				DropLinks:  true,
+				Tables:     models.HTMLTablesHTML,
+			},
+			contains: []string{"Soup", "detail", "<table><tbody><tr><td>Goulash</td><td>159</td></tr></tbody></table>"},
+			missing:  []string{"soup.png", "(/soup)"},
+		},
+		{
+			name:     "markdown with custom tables",
+			html:     models.HTMLFilter{Mode: models.HTMLModeMarkdown, Tables: models.HTMLTablesCustom},
+			contains: []string{"Goulash 159"},
+			missing:  []string{"|"},
+		},
+		{
+			name:     "markdown without the mode ignores the tables",
+			html:     models.HTMLFilter{Tables: models.HTMLTablesCustom},
+			contains: []string{"| Goulash | 159 |"},
+		},
+		{
+			name:     "text",
+			html:     models.HTMLFilter{Mode: models.HTMLModeText, TextOnly: true},
+			contains: []string{"Menu", "Goulash"},
+			missing:  []string{"<", "|"},
+		},
+		{
+			name: "html",
+			html: models.HTMLFilter{Mode: models.HTMLModeHTML},
+			contains: []string{
+				`<h2>Menu</h2>`, `<img src="/soup.png" alt="soup">`, `<a href="/soup">detail</a>`,
+				`<td>Goulash</td>`, `<a>bad</a>`,
+			},
+			missing: []string{"script", "alert", "onclick"},
+		},
+	}
+
+	for _, tt := range tests {
+		page := &models.Page{Filters: models.FiltersConfig{HTML: tt.html}}
+		got, err := NewHTMLModeConverter(page).Filter(content)
+		assert.NoError(err, tt.name)
+		for _, want := range tt.contains {
+			assert.Contains(got, want, tt.name)
+		}
+		for _, notWant := range tt.missing {
+			assert.NotContains(got, notWant, tt.name)
+		}
+	}
+
+	assert.Error(validateHTMLFilter(&models.HTMLFilter{Mode: "pdf"}))
+	assert.Error(validateHTMLFilter(&models.HTMLFilter{Mode: models.HTMLModeMarkdown, Tables: models.HTMLTablesPretty}))
+	assert.NoError(validateHTMLFilter(&models.HTMLFilter{Tables: models.HTMLTablesPretty}))
+	assert.NoError(validateHTMLFilter(&models.HTMLFilter{Mode: models.HTMLModeText, Tables: models.HTMLTablesPretty}))
+}
@@ -23,12 +23,12 @@ type htmlFilterTags struct {
 // Filter implements PageFilter
 // TODO: Pass context
 func (f *htmlFilterTags) Filter(content string) (string, error) {
-	if f.html.Tables != "pretty" {
+	if f.html.Tables != models.HTMLTablesPretty {
 		content = useCustomHTMLTablesConverter(content)
 	}
 
 	text, err := html2text.FromString(content, html2text.Options{
-		PrettyTables: f.html.Tables == "pretty",
+		PrettyTables: f.html.Tables == models.HTMLTablesPretty,
 		TextOnly:     f.html.TextOnly,
 	})
 
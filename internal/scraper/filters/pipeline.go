@@ -0,0 +1,103 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pestanko/miniscrape/internal/models"
+)
+
+// NewPageFilters returns the filters of the page - the declared pipeline steps in order,
+// or the filters configuration in the default order if no pipeline is declared,
+// steps which can not be created are skipped and reported in the error
+func NewPageFilters(page *models.Page) ([]PageFilter, error) {
+	if len(page.Filters.Pipeline) == 0 {
+		return []PageFilter{
+			NewNewLineTrimConverter(page),
+			NewCutFilter(page),
+			NewDayFilter(page),
+			NewCutLineFilter(page),
+			NewRegexFilter(page),
+		}, nil
+	}
+
+	var result []PageFilter
+	var errs []error
+	for idx, step := range page.Filters.Pipeline {
+		filter, err := newStepFilter(page, step)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("filter step %d: %w", idx, err))
+			continue
+		}
+		result = append(result, filter)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// newStepFilter creates the filter of the pipeline step, the filter is created
+// for the copy of the page with the step parameters as the filter configuration
+func newStepFilter(page *models.Page, step models.FilterStep) (PageFilter, error) {
+	stepPage := *page
+	stepPage.Filters = models.FiltersConfig{HTML: page.Filters.HTML}
+
+	switch step.Type {
+	case models.FilterStepNewLine:
+		return NewNewLineTrimConverter(&stepPage), nil
+	case models.FilterStepCut:
+		stepPage.Filters.Cut = step.CutFilter
+		return NewCutFilter(&stepPage), nil
+	case models.FilterStepDay:
+		if err := validateDayFilter(&step.DayFilter); err != nil {
+			return nil, err
+		}
+		stepPage.Filters.Day = step.DayFilter
+		stepPage.Filters.Day.Enabled = true
+		return NewDayFilter(&stepPage), nil
+	case models.FilterStepCutLine:
+		stepPage.Filters.CutLine = step.CutLineFilter
+		return NewCutLineFilter(&stepPage), nil
+	case models.FilterStepRegex:
+		if err := validateRegexFilter(&step.RegexFilter); err != nil {
+			return nil, err
+		}
+		stepPage.Filters.Regex = step.RegexFilter
+		return NewRegexFilter(&stepPage), nil
+	default:
+		return nil, fmt.Errorf("unknown filter type %q", step.Type)
+	}
+}
+
+// ValidatePage checks the filters configuration of the page and its sources
+func ValidatePage(page *models.Page) error {
+	if err := validateFilters(page); err != nil {
+		return err
+	}
+
+	for idx, src := range page.Sources {
+		if src.Filters == nil {
+			continue
+		}
+		srcPage := page.WithSource(src)
+		if err := validateFilters(&srcPage); err != nil {
+			return fmt.Errorf("source %d: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
+func validateFilters(page *models.Page) error {
+	if err := validateHTMLFilter(&page.Filters.HTML); err != nil {
+		return err
+	}
+	if err := validateRegexFilter(&page.Filters.Regex); err != nil {
+		return err
+	}
+	if err := validateDayFilter(&page.Filters.Day); err != nil {
+		return err
+	}
+
+	_, err := NewPageFilters(page)
+	return err
+}
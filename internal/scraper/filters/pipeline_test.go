@@ -0,0 +1,86 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func applyPageFilters(t *testing.T, page *models.Page, content string) string {
+	pageFilters, err := NewPageFilters(page)
+	assert.NoError(t, err)
+
+	for _, filter := range pageFilters {
+		if !filter.IsEnabled() {
+			continue
+		}
+		content, err = filter.Filter(content)
+		assert.NoError(t, err)
+	}
+
+	return content
+}
+
+func TestNewPageFiltersPipeline(t *testing.T) {
+	assert := assert.New(t)
+
+	var page models.Page
+	assert.NoError(yaml.Unmarshal([]byte(`
+filters:
+  pipeline:
+    - type: cut
+      before: "Menu"
+      after: "Contact"
+    - type: cutLine
+      contains: "Allergens"
+    - type: newline
+    - type: cutLine
+      startsWith: "-"
+    - type: regex
+      rules:
+        - find: "(\\d+),-"
+          replace: "$1 CZK"
+`), &page))
+
+	assert.Len(page.Filters.Pipeline, 5)
+	assert.NoError(ValidatePage(&page))
+
+	content := "Header\nMenu\nSoup 45,-\nAllergens: 1, 7\n\n\n- Goulash 159,-\nSteak 259,-\nContact: +420"
+	assert.Equal("Menu\nSoup 45 CZK\nSteak 259 CZK", applyPageFilters(t, &page, content))
+}
+
+func TestNewPageFiltersShorthand(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{Filters: models.FiltersConfig{
+		Cut:     models.CutFilter{Before: "Menu"},
+		CutLine: models.CutLineFilter{Contains: "Allergens"},
+	}}
+
+	pageFilters, err := NewPageFilters(&page)
+	assert.NoError(err)
+
+	var names []string
+	for _, filter := range pageFilters {
+		names = append(names, filter.Name())
+	}
+	assert.Equal([]string{"newline", "cut", "day", "cut_line", "regex"}, names)
+
+	assert.Equal("Menu\nSoup", applyPageFilters(t, &page, "Header\nMenu\n\nAllergens: 1\nSoup\n"))
+}
+
+func TestNewPageFiltersUnknownStep(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{Filters: models.FiltersConfig{
+		Pipeline: []models.FilterStep{{Type: "newline"}, {Type: "shout"}},
+	}}
+
+	pageFilters, err := NewPageFilters(&page)
+
+	assert.Error(err)
+	assert.Len(pageFilters, 1)
+	assert.Error(ValidatePage(&page))
+}
@@ -0,0 +1,99 @@
+package filters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pestanko/miniscrape/internal/models"
+)
+
+// NewRegexFilter create a new instance of the regex filter
+func NewRegexFilter(page *models.Page) PageFilter {
+	return &regexFilter{
+		page.Filters.Regex,
+	}
+}
+
+type regexFilter struct {
+	regex models.RegexFilter
+}
+
+func (f *regexFilter) config() *models.RegexFilter {
+	return &f.regex
+}
+
+func (f *regexFilter) IsEnabled() bool {
+	return f.config().Extract != "" || len(f.config().Rules) != 0
+}
+
+func (*regexFilter) Name() string {
+	return "regex"
+}
+
+func (f *regexFilter) Filter(content string) (string, error) {
+	cfg := f.config()
+
+	if cfg.Extract != "" {
+		extract, err := regexp.Compile(cfg.Extract)
+		if err != nil {
+			return content, fmt.Errorf("invalid extract pattern: %w", err)
+		}
+		content = extractMatches(extract, content)
+	}
+
+	for idx, rule := range cfg.Rules {
+		find, err := regexp.Compile(rule.Find)
+		if err != nil {
+			return content, fmt.Errorf("invalid pattern of the rule %d: %w", idx, err)
+		}
+		content = find.ReplaceAllString(content, rule.Replace)
+	}
+
+	return content, nil
+}
+
+// extractMatches returns all matches of the pattern one per line,
+// if the pattern has named groups, only the named groups are kept
+func extractMatches(pattern *regexp.Regexp, content string) string {
+	var named []int
+	for idx, name := range pattern.SubexpNames() {
+		if name != "" {
+			named = append(named, idx)
+		}
+	}
+
+	var result []string
+	for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+		if len(named) == 0 {
+			result = append(result, strings.TrimSpace(match[0]))
+			continue
+		}
+
+		var groups []string
+		for _, idx := range named {
+			if group := strings.TrimSpace(match[idx]); group != "" {
+				groups = append(groups, group)
+			}
+		}
+		result = append(result, strings.Join(groups, " "))
+	}
+
+	return strings.Join(result, "\n")
+}
+
+func validateRegexFilter(cfg *models.RegexFilter) error {
+	if cfg.Extract != "" {
+		if _, err := regexp.Compile(cfg.Extract); err != nil {
+			return fmt.Errorf("regex filter: invalid extract pattern: %w", err)
+		}
+	}
+
+	for idx, rule := range cfg.Rules {
+		if _, err := regexp.Compile(rule.Find); err != nil {
+			return fmt.Errorf("regex filter: invalid pattern of the rule %d: %w", idx, err)
+		}
+	}
+
+	return nil
+}
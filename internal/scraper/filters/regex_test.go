@@ -0,0 +1,63 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	content := "Lentil soup (1, 7) 45,- Kč\nGoulash (1, 3, 7) 159,- Kč\nReservations: +420 123 456 789"
+
+	tests := []struct {
+		name  string
+		regex models.RegexFilter
+		want  string
+	}{
+		{
+			name: "rules",
+			regex: models.RegexFilter{Rules: []models.RegexRule{
+				{Find: `\s*\([\d, ]+\)`},
+				{Find: `(\d+),-\s*Kč`, Replace: "$1 CZK"},
+				{Find: `(?m)^Reservations:.*$\n?`},
+			}},
+			want: "Lentil soup 45 CZK\nGoulash 159 CZK\n",
+		},
+		{
+			name:  "extract matches",
+			regex: models.RegexFilter{Extract: `\d+,- Kč`},
+			want:  "45,- Kč\n159,- Kč",
+		},
+		{
+			name: "extract named groups",
+			regex: models.RegexFilter{
+				Extract: `(?m)^(?P<dish>[A-Z][a-z ]+?) \(.*\) (?P<price>\d+)`,
+				Rules:   []models.RegexRule{{Find: `(?m)(\d+)$`, Replace: "$1 Kč"}},
+			},
+			want: "Lentil soup 45 Kč\nGoulash 159 Kč",
+		},
+	}
+
+	for _, tt := range tests {
+		filter := NewRegexFilter(&models.Page{Filters: models.FiltersConfig{Regex: tt.regex}})
+		assert.True(filter.IsEnabled(), tt.name)
+
+		result, err := filter.Filter(content)
+		assert.NoError(err, tt.name)
+		assert.Equal(tt.want, result, tt.name)
+	}
+}
+
+func TestRegexFilterValidatePage(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{Filters: models.FiltersConfig{Regex: models.RegexFilter{
+		Rules: []models.RegexRule{{Find: `(unclosed`}},
+	}}}
+
+	assert.Error(ValidatePage(&page))
+	assert.False(NewRegexFilter(&models.Page{}).IsEnabled())
+}
@@ -0,0 +1,230 @@
+// Package menu parses the structured menu from the filtered page content
+package menu
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pestanko/miniscrape/internal/models"
+)
+
+const (
+	defaultCurrency = "CZK"
+
+	defaultDishPattern = `^(?:\d+[.)]\s*)?(?P<name>.+?)\s*` +
+		`(?:[(/](?P<allergens>\d+[a-z]?(?:\s*[,.]\s*\d+[a-z]?)*)[)/])?\s*[-–:.]*\s*` +
+		`(?P<price>\d+(?:[.,]\d{1,2})?)\s*(?P<currency>Kč|CZK|,-|€|EUR)?$`
+
+	defaultVegetarianPattern = `(?i)\b(veg|vegetari\w*|vegan\w*)\b|🌱|\(V\)`
+)
+
+var defaultSectionPatterns = []string{
+	`(?i)^(polévk[ay]|polevk[ay]|soups?)\s*:?$`,
+	`(?i)^(hlavní jídl[ao]|hlavni jidl[ao]|main( courses?)?)\s*:?$`,
+	`(?i)^(saláty|salaty|salads?)\s*:?$`,
+	`(?i)^(dezerty?|desserts?)\s*:?$`,
+}
+
+var (
+	markdownDecoration = regexp.MustCompile(`\*\*|__|[|]`)
+	markdownPrefix     = regexp.MustCompile(`^[\s#>*+-]+`)
+	multipleSpaces     = regexp.MustCompile(`\s+`)
+	allergenSeparator  = regexp.MustCompile(`[\s,.]+`)
+	priceValue         = regexp.MustCompile(`\d+(?:[ \x{00a0}]\d{3})*(?:[.,]\d+)?`)
+)
+
+// Parser parses the menu from the content using the configured patterns
+type Parser struct {
+	sections   []*regexp.Regexp
+	dish       *regexp.Regexp
+	vegetarian *regexp.Regexp
+	currency   string
+}
+
+// NewParser creates a new instance of the parser, default patterns are used
+// for the patterns missing in the configuration
+func NewParser(cfg models.MenuConfig) (*Parser, error) {
+	sectionPatterns := cfg.Sections
+	if len(sectionPatterns) == 0 {
+		sectionPatterns = defaultSectionPatterns
+	}
+
+	parser := &Parser{
+		currency: valueOrDefault(cfg.Currency, defaultCurrency),
+	}
+
+	for _, pattern := range sectionPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid section pattern: %w", err)
+		}
+		parser.sections = append(parser.sections, re)
+	}
+
+	var err error
+	if parser.dish, err = regexp.Compile(valueOrDefault(cfg.Dish, defaultDishPattern)); err != nil {
+		return nil, fmt.Errorf("invalid dish pattern: %w", err)
+	}
+	if parser.dish.SubexpIndex("name") == -1 {
+		return nil, fmt.Errorf("dish pattern has to contain the \"name\" group")
+	}
+	if parser.vegetarian, err = regexp.Compile(valueOrDefault(cfg.Vegetarian, defaultVegetarianPattern)); err != nil {
+		return nil, fmt.Errorf("invalid vegetarian pattern: %w", err)
+	}
+
+	return parser, nil
+}
+
+// ValidatePage checks whether the menu configuration of the page is valid
+func ValidatePage(page *models.Page) error {
+	if !page.Menu.Enabled {
+		return nil
+	}
+
+	_, err := NewParser(page.Menu)
+	return err
+}
+
+// Parse the menu from the content, returns nil if no dish has been found
+func (p *Parser) Parse(content string) *models.Menu {
+	menu := &models.Menu{}
+	var section *models.MenuSection
+
+	for _, line := range strings.Split(content, "\n") {
+		line = cleanLine(line)
+		if line == "" {
+			continue
+		}
+
+		if p.isSection(line) {
+			menu.Sections = append(menu.Sections, models.MenuSection{Name: strings.TrimSuffix(line, ":")})
+			section = &menu.Sections[len(menu.Sections)-1]
+			continue
+		}
+
+		dish, ok := p.parseDish(line)
+		if !ok {
+			continue
+		}
+
+		if section == nil {
+			menu.Sections = append(menu.Sections, models.MenuSection{})
+			section = &menu.Sections[len(menu.Sections)-1]
+		}
+		section.Dishes = append(section.Dishes, dish)
+	}
+
+	if menu.IsEmpty() {
+		return nil
+	}
+
+	return menu
+}
+
+func (p *Parser) isSection(line string) bool {
+	for _, re := range p.sections {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Parser) parseDish(line string) (models.Dish, bool) {
+	match := p.dish.FindStringSubmatch(line)
+	if match == nil {
+		return models.Dish{}, false
+	}
+
+	group := func(name string) string {
+		if idx := p.dish.SubexpIndex(name); idx != -1 {
+			return strings.TrimSpace(match[idx])
+		}
+		return ""
+	}
+
+	name := group("name")
+	if name == "" {
+		return models.Dish{}, false
+	}
+
+	dish := models.Dish{
+		Name:       name,
+		Currency:   normalizeCurrency(group("currency"), p.currency),
+		Vegetarian: p.vegetarian.MatchString(line),
+	}
+
+	if price := group("price"); price != "" {
+		if value, err := parsePriceValue(price); err == nil {
+			dish.Price = value
+		}
+	}
+
+	if allergens := group("allergens"); allergens != "" {
+		for _, code := range allergenSeparator.Split(allergens, -1) {
+			if code != "" {
+				dish.Allergens = append(dish.Allergens, code)
+			}
+		}
+	}
+
+	return dish, true
+}
+
+// cleanLine removes the markdown decorations from the line
+func cleanLine(line string) string {
+	line = markdownDecoration.ReplaceAllString(line, " ")
+	line = markdownPrefix.ReplaceAllString(line, "")
+	line = strings.ReplaceAll(line, `\`, "")
+	return strings.TrimSpace(multipleSpaces.ReplaceAllString(line, " "))
+}
+
+// ParsePrice parses the price, which can use the decimal comma and contain the currency
+// (ex. "159,00", "159 Kč", "1 290,-"), the currency is normalized (ex. "Kč" - "CZK"),
+// the configured currency or the default one is used when the price has no currency
+func ParsePrice(price string, currency string, configured string) (float64, string, bool) {
+	loc := priceValue.FindStringIndex(price)
+	if loc == nil {
+		return 0, "", false
+	}
+
+	value, err := parsePriceValue(price[loc[0]:loc[1]])
+	if err != nil {
+		return 0, "", false
+	}
+
+	if currency == "" {
+		currency = strings.TrimSpace(price[:loc[0]] + price[loc[1]:])
+	}
+
+	fallback := normalizeCurrency(configured, defaultCurrency)
+	return value, normalizeCurrency(currency, fallback), true
+}
+
+// parsePriceValue parses the price number with the decimal comma and the thousands separated by a space
+func parsePriceValue(value string) (float64, error) {
+	value = strings.NewReplacer(" ", "", "\u00a0", "", ",", ".").Replace(value)
+	return strconv.ParseFloat(value, 64)
+}
+
+func normalizeCurrency(currency string, fallback string) string {
+	switch currency {
+	case "":
+		return fallback
+	case "Kč", ",-":
+		return "CZK"
+	case "€":
+		return "EUR"
+	default:
+		return currency
+	}
+}
+
+func valueOrDefault(value string, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMenuWithDefaultPatterns(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(models.MenuConfig{Enabled: true})
+	assert.NoError(err)
+
+	menu := parser.Parse(`**Polévka**
+Gulášová polévka (1,7) 45 Kč
+**Hlavní jídla**
+1. Svíčková na smetaně, knedlík (1,3,7) 159,-
+2. Smažený sýr, hranolky (vegetariánské) 149 Kč
+Informace o alergenech u obsluhy`)
+
+	assert.Equal(&models.Menu{Sections: []models.MenuSection{
+		{
+			Name: "Polévka",
+			Dishes: []models.Dish{
+				{Name: "Gulášová polévka", Price: 45, Currency: "CZK", Allergens: []string{"1", "7"}},
+			},
+		},
+		{
+			Name: "Hlavní jídla",
+			Dishes: []models.Dish{
+				{Name: "Svíčková na smetaně, knedlík", Price: 159, Currency: "CZK", Allergens: []string{"1", "3", "7"}},
+				{Name: "Smažený sýr, hranolky (vegetariánské)", Price: 149, Currency: "CZK", Vegetarian: true},
+			},
+		},
+	}}, menu)
+}
+
+func TestParseMenuWithoutDishesIsNil(t *testing.T) {
+	parser, err := NewParser(models.MenuConfig{Enabled: true})
+	assert.NoError(t, err)
+
+	assert.Nil(t, parser.Parse("Today we are closed"))
+}
+
+func TestNewParserRequiresNameGroup(t *testing.T) {
+	_, err := NewParser(models.MenuConfig{Dish: `^(.+) (\d+)$`})
+	assert.Error(t, err)
+}
+
+func TestParsePrice(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		price      string
+		currency   string
+		configured string
+		value      float64
+		want       string
+	}{
+		{price: "159", value: 159, want: "CZK"},
+		{price: "159,00", value: 159, want: "CZK"},
+		{price: "159.50", configured: "EUR", value: 159.5, want: "EUR"},
+		{price: "159 Kč", configured: "EUR", value: 159, want: "CZK"},
+		{price: "159", configured: "Kč", value: 159, want: "CZK"},
+		{price: "1 290,-", value: 1290, want: "CZK"},
+		{price: "€ 12,50", value: 12.5, want: "EUR"},
+		{price: "12.50", currency: "EUR", value: 12.5, want: "EUR"},
+	}
+
+	for _, tt := range tests {
+		value, currency, ok := ParsePrice(tt.price, tt.currency, tt.configured)
+		assert.True(ok, tt.price)
+		assert.Equal(tt.value, value, tt.price)
+		assert.Equal(tt.want, currency, tt.price)
+	}
+
+	_, _, ok := ParsePrice("free", "", "")
+	assert.False(ok)
+}
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/pestanko/miniscrape/internal/cache"
 	"github.com/pestanko/miniscrape/internal/models"
@@ -9,6 +10,22 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// menuFile name of the cache file with the structured menu provided by the resolver
+const menuFile = "menu.json"
+
+// resultFile name of the cache file with the result details stored next to the content
+const resultFile = "result.json"
+
+// cachedResult details of the result which are not part of the content
+type cachedResult struct {
+	// Kind of the resolved content (ex. "iframe", "content")
+	Kind string `json:"kind"`
+	// Source of the page which provided the content
+	Source string `json:"source,omitempty"`
+	// Resolver of the page source which provided the content
+	Resolver string `json:"resolver,omitempty"`
+}
+
 // NewGetCachedPageResolver a new instance of the cached resolver
 func NewGetCachedPageResolver(page models.Page, cacheInstance cache.Cache) PageResolver {
 	inner := NewPageResolver(page)
@@ -36,18 +53,36 @@ func (c *cachedPageResolver) Resolve(ctx context.Context) models.RunResult {
 		content := string(c.cache.GetContent(cache.Item{
 			Namespace: namespace,
 		}))
+		details := c.loadResult(namespace)
 		return models.RunResult{
-			Page:    c.page,
-			Content: content,
-			Status:  models.RunSuccess,
+			Page:     c.page,
+			Content:  content,
+			Status:   models.RunSuccess,
+			Kind:     details.Kind,
+			Source:   details.Source,
+			Resolver: details.Resolver,
+			Menu:     c.loadMenu(namespace),
 		}
 	}
 
+	ctx = withResponseCache(ctx, c.cache, namespace)
 	res := c.resolver.Resolve(ctx)
 	if res.Status != models.RunSuccess {
 		return res
 	}
 
+	// the menu and the details have to be stored before the content, the page is cached once the content is stored
+	if err := c.storeMenu(namespace, res.Menu); err != nil {
+		return makeErrorResult(c.page, err)
+	}
+	if err := c.storeResult(namespace, cachedResult{
+		Kind:     res.Kind,
+		Source:   res.Source,
+		Resolver: res.Resolver,
+	}); err != nil {
+		return makeErrorResult(c.page, err)
+	}
+
 	err := c.cache.Store(cache.Item{
 		Namespace:   namespace,
 		CachePolicy: c.page.CachePolicy,
@@ -58,3 +93,75 @@ func (c *cachedPageResolver) Resolve(ctx context.Context) models.RunResult {
 
 	return res
 }
+
+// loadMenu loads the structured menu provided by the resolver from the cache
+func (c *cachedPageResolver) loadMenu(namespace cache.ItemNamespace) *models.Menu {
+	item := cache.Item{
+		Namespace: namespace,
+		FileName:  menuFile,
+	}
+	if !c.cache.IsItemCached(item) {
+		return nil
+	}
+
+	content := c.cache.GetContent(item)
+
+	var menu models.Menu
+	if err := json.Unmarshal(content, &menu); err != nil {
+		log.Warn().Err(err).Str("pageNamespace", c.page.Namespace()).Msg("Unable to load the cached menu")
+		return nil
+	}
+
+	return &menu
+}
+
+func (c *cachedPageResolver) storeMenu(namespace cache.ItemNamespace, menu *models.Menu) error {
+	if menu == nil {
+		return nil
+	}
+
+	content, err := json.Marshal(menu)
+	if err != nil {
+		return err
+	}
+
+	return c.cache.Store(cache.Item{
+		Namespace:   namespace,
+		FileName:    menuFile,
+		CachePolicy: c.page.CachePolicy,
+	}, content)
+}
+
+// loadResult loads the result details stored next to the content,
+// empty details if they are not stored (ex. the content cached by the older version)
+func (c *cachedPageResolver) loadResult(namespace cache.ItemNamespace) cachedResult {
+	var details cachedResult
+
+	item := cache.Item{
+		Namespace: namespace,
+		FileName:  resultFile,
+	}
+	if !c.cache.IsItemCached(item) {
+		return details
+	}
+
+	if err := json.Unmarshal(c.cache.GetContent(item), &details); err != nil {
+		log.Warn().Err(err).Str("pageNamespace", c.page.Namespace()).Msg("Unable to load the cached result details")
+		return cachedResult{}
+	}
+
+	return details
+}
+
+func (c *cachedPageResolver) storeResult(namespace cache.ItemNamespace, details cachedResult) error {
+	content, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return c.cache.Store(cache.Item{
+		Namespace:   namespace,
+		FileName:    resultFile,
+		CachePolicy: c.page.CachePolicy,
+	}, content)
+}
@@ -0,0 +1,32 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/cache"
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedPageResolverKeepsResultDetails(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{
+		CodeName: "bistro",
+		Category: "food",
+		URL:      "https://bistro.example/menu",
+		Resolver: "iframe",
+		Iframe:   models.IframeConfig{URLOnly: true},
+	}
+	c := cache.NewCache(config.CacheCfg{Enabled: true, Root: t.TempDir()}, time.Now())
+
+	first := NewGetCachedPageResolver(page, c).Resolve(t.Context())
+	cached := NewGetCachedPageResolver(page, c).Resolve(t.Context())
+
+	assert.Equal(models.RunSuccess, first.Status)
+	assert.Equal("iframe", first.Kind)
+	assert.Equal(first.Content, cached.Content)
+	assert.Equal("iframe", cached.Kind)
+}
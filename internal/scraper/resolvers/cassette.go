@@ -0,0 +1,256 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pestanko/miniscrape/internal/config"
+)
+
+// ErrCassetteMiss the request has not been recorded in the cassette
+var ErrCassetteMiss = errors.New("request not found in the cassette")
+
+// cassetteSensitiveHeaders response headers which are never recorded, they can contain session tokens
+var cassetteSensitiveHeaders = []string{"Set-Cookie", "Set-Cookie2", "Authorization", "Proxy-Authorization"}
+
+// cassetteSensitiveParams query parameters which are never recorded, they usually contain credentials
+var cassetteSensitiveParams = []string{
+	"password", "passwd", "pwd", "pass", "token", "access_token", "api_key", "apikey", "secret",
+}
+
+type cassetteSensitiveParamsKey struct{}
+
+// withCassetteSensitiveParams marks the query parameters of the requests in the context
+// which contain credentials (ex. the login fields), they are not recorded to the cassette
+func withCassetteSensitiveParams(ctx context.Context, params ...string) context.Context {
+	return context.WithValue(ctx, cassetteSensitiveParamsKey{}, params)
+}
+
+// cassetteEntry recorded request/response pair
+type cassetteEntry struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Body is used only to identify the request, it is not stored as it can contain credentials
+	Body []byte `json:"-"`
+}
+
+type cassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// cassetteTransport records the request/response pairs to the cassette directory
+// or replays them back without the network, based on the configured mode
+type cassetteTransport struct {
+	next http.RoundTripper
+
+	mu  sync.RWMutex
+	cfg config.CassetteCfg
+}
+
+var defaultCassetteTransport = &cassetteTransport{
+	next: http.DefaultTransport,
+}
+
+// ConfigureCassette sets the cassette mode of the outbound page requests
+func ConfigureCassette(cfg config.CassetteCfg) error {
+	return defaultCassetteTransport.configure(cfg)
+}
+
+func (t *cassetteTransport) configure(cfg config.CassetteCfg) error {
+	switch cfg.GetMode() {
+	case config.CassetteModeOff, config.CassetteModeRecord, config.CassetteModeReplay:
+	default:
+		return fmt.Errorf("unknown cassette mode %q", cfg.Mode)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cfg = cfg
+
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	cfg := t.cfg
+	t.mu.RUnlock()
+
+	switch cfg.GetMode() {
+	case config.CassetteModeRecord:
+		return t.record(cfg.GetDir(), req)
+	case config.CassetteModeReplay:
+		return t.replay(cfg.GetDir(), req)
+	default:
+		return t.next.RoundTrip(req)
+	}
+}
+
+func (t *cassetteTransport) record(dir string, req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := cassetteEntry{
+		Request: cassetteRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   reqBody,
+		},
+		Response: cassetteResponse{
+			StatusCode: res.StatusCode,
+			Header:     redactCassetteHeader(res.Header),
+			Body:       resBody,
+		},
+	}
+
+	// the path is computed from the full request, so the replayed request matches it,
+	// only the stored URL is redacted
+	fp := cassettePath(dir, &entry.Request)
+	entry.Request.URL = redactCassetteURL(req)
+
+	if err := t.store(fp, &entry); err != nil {
+		return nil, fmt.Errorf("unable to record the response: %w", err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	return res, nil
+}
+
+func (t *cassetteTransport) store(fp string, entry *cassetteEntry) error {
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(fp), 0o750); err != nil {
+		return err
+	}
+
+	return os.WriteFile(fp, content, 0o600)
+}
+
+func (t *cassetteTransport) replay(dir string, req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fp := cassettePath(dir, &cassetteRequest{Method: req.Method, URL: req.URL.String(), Body: reqBody})
+	content, err := os.ReadFile(filepath.Clean(fp))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s", ErrCassetteMiss, req.Method, req.URL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cassetteEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, fmt.Errorf("invalid cassette entry %q: %w", fp, err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Response.StatusCode, http.StatusText(entry.Response.StatusCode)),
+		StatusCode:    entry.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Response.Header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Response.Body)),
+		ContentLength: int64(len(entry.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// redactCassetteHeader returns a copy of the response header without the session tokens
+func redactCassetteHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, key := range cassetteSensitiveHeaders {
+		redacted.Del(key)
+	}
+	return redacted
+}
+
+// redactCassetteURL returns the request URL without the user info and the credential query parameters
+func redactCassetteURL(req *http.Request) string {
+	redacted := *req.URL
+	redacted.User = nil
+
+	query := redacted.Query()
+	params, _ := req.Context().Value(cassetteSensitiveParamsKey{}).([]string)
+	for key := range query {
+		if slices.Contains(params, key) || slices.Contains(cassetteSensitiveParams, strings.ToLower(key)) {
+			query.Del(key)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+
+	return redacted.String()
+}
+
+// readRequestBody reads the request body and replaces it, so it can be sent
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// cassettePath path of the recorded request, the request is identified
+// by its method, URL and body - headers are ignored as the User-Agent is random
+func cassettePath(dir string, req *cassetteRequest) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL + "\n"))
+	hash.Write(req.Body)
+
+	host := "unknown"
+	if parsed, err := url.Parse(req.URL); err == nil && parsed.Host != "" {
+		host = strings.ReplaceAll(parsed.Host, ":", "_")
+	}
+
+	return filepath.Join(dir, host, strings.ToLower(req.Method)+"-"+hex.EncodeToString(hash.Sum(nil))[:16]+".json")
+}
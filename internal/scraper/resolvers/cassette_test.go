@@ -0,0 +1,103 @@
+package resolvers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("menu for " + r.URL.Query().Get("day")))
+	}))
+
+	transport := &cassetteTransport{next: http.DefaultTransport}
+	client := http.Client{Transport: transport}
+	dir := t.TempDir()
+
+	get := func(url string) (int, string, error) {
+		res, err := client.Get(url)
+		if err != nil {
+			return 0, "", err
+		}
+		defer func() { _ = res.Body.Close() }()
+		body, err := io.ReadAll(res.Body)
+		return res.StatusCode, string(body), err
+	}
+
+	assert.NoError(transport.configure(config.CassetteCfg{Mode: config.CassetteModeRecord, Dir: dir}))
+	status, body, err := get(server.URL + "?day=monday")
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("menu for monday", body)
+
+	server.Close()
+
+	assert.NoError(transport.configure(config.CassetteCfg{Mode: config.CassetteModeReplay, Dir: dir}))
+	status, body, err = get(server.URL + "?day=monday")
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, status)
+	assert.Equal("menu for monday", body)
+
+	_, _, err = get(server.URL + "?day=tuesday")
+	assert.ErrorIs(err, ErrCassetteMiss)
+
+	assert.Error(transport.configure(config.CassetteCfg{Mode: "rewind"}))
+}
+
+func TestCassetteRecordRedactsCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cr3t-session"})
+		w.Header().Set("Authorization", "Bearer s3cr3t-token")
+		_, _ = w.Write([]byte("welcome"))
+	}))
+	defer server.Close()
+
+	transport := &cassetteTransport{next: http.DefaultTransport}
+	dir := t.TempDir()
+	assert.NoError(transport.configure(config.CassetteCfg{Mode: config.CassetteModeRecord, Dir: dir}))
+
+	ctx := withCassetteSensitiveParams(t.Context(), "login", "heslo")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/login?login=jan&heslo=s3cr3t-pass&token=s3cr3t-api&lang=cs", nil)
+	assert.NoError(err)
+
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if !assert.NoError(err) {
+		return
+	}
+	_ = res.Body.Close()
+	assert.NotEmpty(res.Header.Get("Set-Cookie"), "the live response keeps the cookies")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	assert.NoError(err)
+	if !assert.Len(files, 1) {
+		return
+	}
+
+	content, err := os.ReadFile(files[0])
+	assert.NoError(err)
+	for _, secret := range []string{"s3cr3t", "jan", "Set-Cookie", "Authorization"} {
+		assert.NotContains(string(content), secret)
+	}
+	assert.Contains(string(content), "lang=cs")
+
+	assert.NoError(transport.configure(config.CassetteCfg{Mode: config.CassetteModeReplay, Dir: dir}))
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/login?login=jan&heslo=s3cr3t-pass&token=s3cr3t-api&lang=cs", nil)
+	assert.NoError(err)
+	res, err = (&http.Client{Transport: transport}).Do(req)
+	if assert.NoError(err) {
+		_ = res.Body.Close()
+		assert.Equal(http.StatusOK, res.StatusCode)
+	}
+}
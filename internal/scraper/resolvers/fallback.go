@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// fallbackResolver tries the page sources in order,
+// until one of them returns non-empty content, the source and its resolver are recorded only on success
+type fallbackResolver struct {
+	page models.Page
+}
+
+// Resolve implements PageResolver
+func (r *fallbackResolver) Resolve(ctx context.Context) models.RunResult {
+	ll := zerolog.Ctx(ctx)
+
+	var res models.RunResult
+	for idx, src := range r.page.Sources {
+		srcPage := r.page.WithSource(src)
+		label := src.Label()
+		if label == "" {
+			label = srcPage.URL
+		}
+
+		res = NewPageResolver(srcPage).Resolve(ctx)
+		res.Page = r.page
+
+		if res.Status == models.RunSuccess && strings.TrimSpace(res.Content) != "" {
+			res.Source = label
+			res.Resolver = srcPage.Resolver
+			ll.Debug().
+				Int("source_idx", idx).
+				Str("source", label).
+				Msg("Content resolved using the source")
+			return res
+		}
+
+		ll.Warn().
+			Int("source_idx", idx).
+			Str("source", label).
+			Str("status", string(res.Status)).
+			Msg("Source has not provided the content - trying next")
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/cache"
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallbackResolverUsesFirstSourceWithContent(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/down", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body><div id="other">Nothing</div></body></html>`)
+	})
+	mux.HandleFunc("/mirror", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body><div class="post">Lentil soup</div></body></html>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "test",
+		URL:      server.URL + "/down",
+		Resolver: "default",
+		Query:    "#menu",
+		Sources: []models.PageSource{
+			{Name: "web"},
+			{Name: "empty", URL: server.URL + "/empty"},
+			{Name: "mirror", URL: server.URL + "/mirror", Query: ".post"},
+		},
+	}
+
+	assert.NoError(ValidatePage(&page))
+
+	res := NewPageResolver(page).Resolve(t.Context())
+
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Equal("Lentil soup", res.Content)
+	assert.Equal("mirror", res.Source)
+	assert.Equal(page.URL, res.Page.URL)
+}
+
+func TestFallbackResolverSourceOnlyOnSuccess(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/down", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/mirror", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body><div id="menu">Lentil soup</div></body></html>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "test",
+		Category: "food",
+		URL:      server.URL + "/down",
+		Resolver: "default",
+		Query:    "#menu",
+		Sources:  []models.PageSource{{Name: "web"}, {Name: "backup", URL: server.URL + "/down"}},
+	}
+
+	res := NewPageResolver(page).Resolve(t.Context())
+	assert.Equal(models.RunError, res.Status)
+	assert.Empty(res.Source)
+
+	page.Sources = append(page.Sources, models.PageSource{Name: "mirror", URL: server.URL + "/mirror"})
+	c := cache.NewCache(config.CacheCfg{Enabled: true, Root: t.TempDir()}, time.Now())
+
+	first := NewGetCachedPageResolver(page, c).Resolve(t.Context())
+	cached := NewGetCachedPageResolver(page, c).Resolve(t.Context())
+
+	assert.Equal("mirror", first.Source)
+	assert.Equal("Lentil soup", cached.Content)
+	assert.Equal("mirror", cached.Source)
+}
+
+func TestFallbackResolverReportsSourceResolver(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/menu.pdf", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/menu.html", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body><div id="menu"><img src="/week.png"></div></body></html>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "test",
+		Category: "food",
+		URL:      server.URL + "/menu.html",
+		Resolver: "default",
+		Query:    "#menu",
+		Sources: []models.PageSource{
+			{Name: "pdf", URL: server.URL + "/menu.pdf", Resolver: "pdf"},
+			{Name: "image", Resolver: "img"},
+		},
+	}
+
+	assert.NoError(ValidatePage(&page))
+
+	c := cache.NewCache(config.CacheCfg{Enabled: true, Root: t.TempDir()}, time.Now())
+	first := NewGetCachedPageResolver(page, c).Resolve(t.Context())
+	cached := NewGetCachedPageResolver(page, c).Resolve(t.Context())
+
+	for _, res := range []models.RunResult{first, cached} {
+		assert.Equal(models.RunSuccess, res.Status)
+		assert.Equal("image", res.Source)
+		assert.Equal("img", res.Kind)
+		assert.Equal("img", res.ResolverName())
+		assert.Equal("default", res.Page.Resolver)
+	}
+}
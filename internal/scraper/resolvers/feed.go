@@ -0,0 +1,232 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/filters"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/html/charset"
+)
+
+var feedDateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02",
+}
+
+type feedResolver struct {
+	page    models.Page
+	filters []func(*models.Page) filters.PageFilter
+}
+
+// feedEntry common representation of RSS item and Atom entry
+type feedEntry struct {
+	Title     string
+	Link      string
+	Content   string
+	Published time.Time
+}
+
+type rssDocument struct {
+	Items []struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+		PubDate     string `xml:"pubDate"`
+	} `xml:"channel>item"`
+}
+
+type atomDocument struct {
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Content   string `xml:"content"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+// Resolve implements PageResolver
+func (r *feedResolver) Resolve(ctx context.Context) models.RunResult {
+	ll := zerolog.Ctx(ctx).With().
+		Str("page_url", r.page.URL).
+		Logger()
+
+	ll.Debug().Msg("Resolving feed")
+
+	bodyContent, err := getRawContentForWebPage(ctx, &r.page)
+	if err != nil {
+		return makeErrorResult(r.page, err)
+	}
+
+	entries, err := parseFeed(bodyContent)
+	if err != nil {
+		ll.Warn().Err(err).Msg("Feed parsing failed")
+		return makeParseErrorResult(r.page, err)
+	}
+
+	entries, err = r.selectEntries(entries, time.Now())
+	if err != nil {
+		return makeErrorResult(r.page, err)
+	}
+
+	if len(entries) == 0 {
+		ll.Warn().Msg("No feed entries found")
+		return makeEmptyResult(r.page, "feed")
+	}
+
+	content := applyFilters(ctx, &r.page, r.filters, renderFeedEntries(entries))
+	if content == "" {
+		ll.Warn().Msg("Content resolved but the content is empty")
+		return makeEmptyResult(r.page, "feed")
+	}
+
+	ll.Debug().Int("entries", len(entries)).Msg("Content resolved")
+
+	return models.RunResult{
+		Page:    r.page,
+		Status:  models.RunSuccess,
+		Content: content,
+		Kind:    "feed",
+	}
+}
+
+func (r *feedResolver) selectEntries(entries []feedEntry, now time.Time) ([]feedEntry, error) {
+	cfg := r.page.Feed
+
+	var titlePattern *regexp.Regexp
+	if cfg.Title != "" {
+		var err error
+		if titlePattern, err = regexp.Compile(cfg.Title); err != nil {
+			return nil, fmt.Errorf("invalid feed title pattern: %w", err)
+		}
+	}
+
+	var result []feedEntry
+	for _, entry := range entries {
+		if titlePattern != nil && !titlePattern.MatchString(entry.Title) {
+			continue
+		}
+		if cfg.Days > 0 && !entry.Published.IsZero() &&
+			entry.Published.Before(now.AddDate(0, 0, -cfg.Days)) {
+			continue
+		}
+
+		result = append(result, entry)
+		if cfg.Limit > 0 && len(result) == cfg.Limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// parseFeed parses both RSS and Atom feeds
+func parseFeed(content []byte) ([]feedEntry, error) {
+	var rss rssDocument
+	if err := decodeFeedXML(content, &rss); err != nil {
+		return nil, err
+	}
+
+	var entries []feedEntry
+	for _, item := range rss.Items {
+		body := item.Encoded
+		if body == "" {
+			body = item.Description
+		}
+		entries = append(entries, feedEntry{
+			Title:     item.Title,
+			Link:      item.Link,
+			Content:   body,
+			Published: parseFeedDate(item.PubDate),
+		})
+	}
+
+	if len(entries) != 0 {
+		return entries, nil
+	}
+
+	var atom atomDocument
+	if err := decodeFeedXML(content, &atom); err != nil {
+		return nil, err
+	}
+
+	for _, item := range atom.Entries {
+		var link string
+		for _, l := range item.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		body := item.Content
+		if body == "" {
+			body = item.Summary
+		}
+		published := item.Published
+		if published == "" {
+			published = item.Updated
+		}
+		entries = append(entries, feedEntry{
+			Title:     item.Title,
+			Link:      link,
+			Content:   body,
+			Published: parseFeedDate(published),
+		})
+	}
+
+	return entries, nil
+}
+
+func decodeFeedXML(content []byte, v any) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+	return decoder.Decode(v)
+}
+
+func parseFeedDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// renderFeedEntries renders the entries as HTML, so it can go through the html filters
+func renderFeedEntries(entries []feedEntry) string {
+	var sb strings.Builder
+	for _, entry := range entries {
+		fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(strings.TrimSpace(entry.Title)))
+		if !entry.Published.IsZero() {
+			fmt.Fprintf(&sb, "<p><em>%s</em></p>\n", entry.Published.Format("2006-01-02 15:04"))
+		}
+		fmt.Fprintf(&sb, "<div>%s</div>\n", entry.Content)
+		if entry.Link != "" {
+			fmt.Fprintf(&sb, "<p><a href=\"%s\">%s</a></p>\n",
+				html.EscapeString(entry.Link), html.EscapeString(entry.Link))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRssFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+<channel>
+	<title>Bakery</title>
+	<item>
+		<title>Weekly special</title>
+		<link>https://example.com/special</link>
+		<description>&lt;p&gt;Poppy seed kolach&lt;/p&gt;</description>
+		<pubDate>Fri, 16 Oct 2026 08:00:00 +0200</pubDate>
+	</item>
+	<item>
+		<title>Old news</title>
+		<link>https://example.com/old</link>
+		<description>Closed for holidays</description>
+		<pubDate>Mon, 03 Aug 2026 08:00:00 +0200</pubDate>
+	</item>
+</channel>
+</rss>`
+
+const testAtomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Cinema</title>
+	<entry>
+		<title>Premiere: Dune</title>
+		<link href="https://example.com/dune"/>
+		<summary>Tonight at 20:00</summary>
+		<updated>2026-10-17T18:00:00Z</updated>
+	</entry>
+</feed>`
+
+func TestParseRssFeed(t *testing.T) {
+	assert := assert.New(t)
+
+	entries, err := parseFeed([]byte(testRssFeed))
+
+	assert.NoError(err)
+	assert.Len(entries, 2)
+	assert.Equal("Weekly special", entries[0].Title)
+	assert.Equal("https://example.com/special", entries[0].Link)
+	assert.Equal("<p>Poppy seed kolach</p>", entries[0].Content)
+	assert.Equal(16, entries[0].Published.Day())
+}
+
+func TestParseAtomFeed(t *testing.T) {
+	assert := assert.New(t)
+
+	entries, err := parseFeed([]byte(testAtomFeed))
+
+	assert.NoError(err)
+	assert.Len(entries, 1)
+	assert.Equal("Premiere: Dune", entries[0].Title)
+	assert.Equal("https://example.com/dune", entries[0].Link)
+	assert.Equal("Tonight at 20:00", entries[0].Content)
+}
+
+func TestFeedSelectEntriesByDateWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	entries, err := parseFeed([]byte(testRssFeed))
+	assert.NoError(err)
+
+	resolver := &feedResolver{page: models.Page{Feed: models.FeedConfig{Days: 7}}}
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	selected, err := resolver.selectEntries(entries, now)
+
+	assert.NoError(err)
+	assert.Len(selected, 1)
+	assert.Equal("Weekly special", selected[0].Title)
+}
+
+func TestFeedSelectEntriesByTitle(t *testing.T) {
+	assert := assert.New(t)
+
+	entries, err := parseFeed([]byte(testRssFeed))
+	assert.NoError(err)
+
+	resolver := &feedResolver{page: models.Page{Feed: models.FeedConfig{Title: "(?i)^old"}}}
+
+	selected, err := resolver.selectEntries(entries, time.Now())
+
+	assert.NoError(err)
+	assert.Len(selected, 1)
+	assert.Equal("Old news", selected[0].Title)
+}
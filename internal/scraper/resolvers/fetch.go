@@ -0,0 +1,212 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// pageResponse represents a fully read HTTP response
+type pageResponse struct {
+	// URL of the response, it differs from the page URL if the request has been redirected
+	URL string
+	// StatusCode of the response
+	StatusCode int
+	// Header of the response
+	Header http.Header
+	// Body content of the response
+	Body []byte
+}
+
+// fetchPage fetches the page content, transient failures of the idempotent requests are retried
+// and requests to the same host are rate limited
+func fetchPage(ctx context.Context, page *models.Page) (*pageResponse, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("page.url", page.URL))
+
+	defer func() {
+		span.End()
+	}()
+
+	ll := zerolog.Ctx(ctx).With().
+		Str("page_url", page.URL).
+		Str("page_namespace", page.Namespace()).
+		Logger()
+
+	reqURL, err := url.Parse(page.URL)
+	if err != nil {
+		ll.Err(err).Msg("Invalid page url")
+		return nil, err
+	}
+
+	cfg := page.Fetch
+	retries := cfg.Retries
+	if !isIdempotentRequest(page) && !cfg.RetryNonIdempotent {
+		retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := defaultHostLimiter.Wait(ctx, reqURL.Host, cfg.HostInterval); err != nil {
+			return nil, err
+		}
+
+		res, err := fetchPageOnce(ctx, page, &ll)
+		if attempt >= retries || ctx.Err() != nil || !isTransientFailure(res, err) {
+			return checkPageResponse(page, res, err)
+		}
+
+		delay := retryBackoff(cfg, attempt)
+		ll.Warn().
+			Err(err).
+			Int("attempt", attempt+1).
+			Dur("delay", delay).
+			Msg("Request failed - retrying")
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func fetchPageOnce(ctx context.Context, page *models.Page, ll *zerolog.Logger) (*pageResponse, error) {
+	timeout := page.Request.Timeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := newPageRequest(ctx, page)
+	if err != nil {
+		ll.Err(err).
+			Msg("Request initialization failed")
+		return nil, err
+	}
+
+	respCache := responseCacheFromContext(ctx)
+	respCache.addValidators(req)
+
+	res, err := pageClient(page).Do(req)
+
+	if res == nil {
+		ll.Error().
+			Err(err).
+			Msg("Request failed - empty response")
+		return nil, err
+	}
+
+	if err != nil {
+		ll.Error().
+			Err(err).
+			Int("status", res.StatusCode).
+			Msg("Request failed")
+		ll.Trace().
+			Stack().
+			Err(err).
+			Str("content", fmt.Sprintf("%v", res)).
+			Msg("Error response content")
+		return nil, err
+	}
+
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			ll.Error().
+				Err(err).
+				Int("status", res.StatusCode).
+				Msg("Unable to close body")
+		}
+	}()
+
+	bodyContent, err := io.ReadAll(res.Body)
+	if err != nil {
+		ll.Error().
+			Err(err).
+			Int("status", res.StatusCode).
+			Msg("Failed to read a body")
+
+		return nil, err
+	}
+
+	pageRes := &pageResponse{
+		URL:        res.Request.URL.String(),
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       bodyContent,
+	}
+	respCache.handleResponse(ctx, req.URL.String(), pageRes)
+
+	return pageRes, nil
+}
+
+// checkPageResponse converts the failed request or non-success response to the scrape error
+func checkPageResponse(page *models.Page, res *pageResponse, err error) (*pageResponse, error) {
+	if err != nil {
+		return nil, models.AsScrapeError(err)
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return nil, models.NewHTTPStatusError(res.StatusCode, page.URL)
+	}
+
+	return res, nil
+}
+
+// isIdempotentRequest whether the page request can be safely sent again
+func isIdempotentRequest(page *models.Page) bool {
+	switch strings.ToUpper(page.Request.Method) {
+	case "", http.MethodGet, http.MethodHead:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransientFailure whether the request failure is worth to retry
+func isTransientFailure(res *pageResponse, err error) bool {
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout() ||
+			errors.Is(err, context.DeadlineExceeded) ||
+			errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+
+	return res != nil &&
+		(res.StatusCode >= http.StatusInternalServerError || res.StatusCode == http.StatusTooManyRequests)
+}
+
+// retryBackoff exponential backoff with jitter for the attempt (starting from 0)
+func retryBackoff(cfg config.FetchCfg, attempt int) time.Duration {
+	delay := cfg.Backoff
+	if delay <= 0 {
+		delay = defaultRetryBackoff
+	}
+
+	for i := 0; i < attempt && (cfg.MaxBackoff <= 0 || delay < cfg.MaxBackoff); i++ {
+		delay *= 2
+	}
+
+	if cfg.MaxBackoff > 0 && delay > cfg.MaxBackoff {
+		delay = cfg.MaxBackoff
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)) // #nosec G404
+}
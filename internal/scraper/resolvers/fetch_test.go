@@ -0,0 +1,119 @@
+package resolvers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchPageRetriesServerErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("menu"))
+	}))
+	defer server.Close()
+
+	page := models.Page{
+		URL:   server.URL,
+		Fetch: config.FetchCfg{Retries: 2, Backoff: time.Millisecond},
+	}
+
+	res, err := fetchPage(t.Context(), &page)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal("menu", string(res.Body))
+	assert.Equal(int32(3), calls.Load())
+}
+
+func TestRetryBackoffIsCapped(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := config.FetchCfg{Backoff: time.Second, MaxBackoff: 4 * time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := retryBackoff(cfg, attempt)
+		assert.LessOrEqual(delay, cfg.MaxBackoff)
+		assert.GreaterOrEqual(delay, cfg.Backoff/2)
+	}
+}
+
+func TestFetchPageNotFoundIsTypedError(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	page := models.Page{URL: server.URL}
+
+	res, err := fetchPage(t.Context(), &page)
+
+	assert.Nil(res)
+	scrapeErr := models.AsScrapeError(err)
+	assert.Equal(models.ErrKindHTTPStatus, scrapeErr.Kind)
+	assert.Equal(http.StatusNotFound, scrapeErr.StatusCode)
+}
+
+func TestFetchPageUsesPageTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		_, _ = w.Write([]byte("menu"))
+	}))
+	defer server.Close()
+
+	page := models.Page{
+		URL:     server.URL,
+		Request: models.RequestConfig{Timeout: 50 * time.Millisecond},
+		Fetch:   config.FetchCfg{Retries: -1},
+	}
+
+	_, err := fetchPage(t.Context(), &page)
+
+	assert.Equal(models.ErrKindTimeout, models.AsScrapeError(err).Kind)
+	assert.Zero(httpClient.Timeout, "the page timeout must not be capped by the client timeout")
+}
+
+func TestFetchPageRetriesOnlyIdempotentRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	page := models.Page{
+		URL:     server.URL,
+		Request: models.RequestConfig{Method: http.MethodPost, Form: map[string]string{"day": "monday"}},
+		Fetch:   config.FetchCfg{Retries: 2, Backoff: time.Millisecond},
+	}
+
+	_, err := fetchPage(t.Context(), &page)
+	assert.Error(err)
+	assert.Equal(int32(1), calls.Load())
+
+	calls.Store(0)
+	page.Fetch.RetryNonIdempotent = true
+
+	_, err = fetchPage(t.Context(), &page)
+	assert.Error(err)
+	assert.Equal(int32(3), calls.Load())
+}
@@ -0,0 +1,95 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// fixtureResolver resolves the page content from the local fixture file,
+// the content goes through the same parsing and filters as the default resolver
+type fixtureResolver struct {
+	page models.Page
+}
+
+// Resolve implements PageResolver
+func (r *fixtureResolver) Resolve(ctx context.Context) models.RunResult {
+	fixturePage := r.page
+	fixturePage.URL = fileURL(r.page.Fixture)
+	fixturePage.Resolver = "default"
+
+	res := NewPageResolver(fixturePage).Resolve(ctx)
+	res.Page = r.page
+
+	return res
+}
+
+// fixturesRoot root directory the local files can be read from
+var fixturesRoot = struct {
+	sync.RWMutex
+	root string
+}{
+	root: config.FixturesCfg{}.GetRoot(),
+}
+
+// ConfigureFixtures sets the root directory the local files can be read from,
+// the files outside the root are never read, even if their URL is found at runtime
+func ConfigureFixtures(cfg config.FixturesCfg) {
+	fixturesRoot.Lock()
+	defer fixturesRoot.Unlock()
+
+	fixturesRoot.root = cfg.GetRoot()
+}
+
+func getFixturesRoot() string {
+	fixturesRoot.RLock()
+	defer fixturesRoot.RUnlock()
+
+	return fixturesRoot.root
+}
+
+// isFileURL whether the page URL points to the local file
+func isFileURL(pageURL string) bool {
+	parsed, err := url.Parse(pageURL)
+	return err == nil && parsed.Scheme == "file"
+}
+
+// fileURL creates the file URL for the local file path
+func fileURL(fp string) string {
+	if abs, err := filepath.Abs(fp); err == nil {
+		fp = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(fp)}).String()
+}
+
+// getContentByFile reads the content of the local file the page URL points to
+func getContentByFile(ctx context.Context, page *models.Page) ([]byte, error) {
+	parsed, err := url.Parse(page.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	fp := models.FilePathFromURL(parsed)
+
+	zerolog.Ctx(ctx).Debug().
+		Str("file", fp).
+		Str("page_namespace", page.Namespace()).
+		Msg("Resolve using local file")
+
+	if err := models.CheckFileInRoot(getFixturesRoot(), fp); err != nil {
+		return nil, models.NewScrapeError(models.ErrKindUnknown, err)
+	}
+
+	content, err := os.ReadFile(filepath.Clean(fp))
+	if err != nil {
+		return nil, models.NewScrapeError(models.ErrKindUnknown, err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,78 @@
+package resolvers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixtureResolverAndFileURL(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	useFixturesRoot(t, root)
+
+	fixture := filepath.Join(root, "menu.html")
+	assert.NoError(os.WriteFile(fixture, []byte(`<html><body><div id="menu"><p>Lentil soup</p></div></body></html>`), 0o600))
+
+	page := models.Page{
+		CodeName: "test",
+		Resolver: "fixture",
+		Fixture:  fixture,
+		Query:    "#menu",
+	}
+
+	assert.NoError(ValidatePage(&page))
+
+	res := NewPageResolver(page).Resolve(t.Context())
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Equal("Lentil soup", res.Content)
+	assert.Equal("fixture", res.Page.Resolver)
+
+	page.Resolver = "default"
+	page.URL = fileURL(fixture)
+
+	res = NewPageResolver(page).Resolve(t.Context())
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Equal("Lentil soup", res.Content)
+}
+
+func TestFileURLOutsideFixturesRoot(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	useFixturesRoot(t, root)
+
+	outside := filepath.Join(t.TempDir(), "secret.html")
+	assert.NoError(os.WriteFile(outside, []byte(`<div id="menu">classified</div>`), 0o600))
+	assert.NoError(os.Symlink(outside, filepath.Join(root, "link.html")))
+
+	for _, fp := range []string{outside, filepath.Join(root, "link.html")} {
+		page := models.Page{CodeName: "test", Resolver: "default", URL: fileURL(fp), Query: "#menu"}
+
+		res := NewPageResolver(page).Resolve(t.Context())
+		assert.Equal(models.RunError, res.Status, fp)
+		assert.NotContains(res.Content, "classified", fp)
+	}
+}
+
+func TestFixtureResolverRequiresFixture(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{CodeName: "test", Resolver: "fixture", Query: "#menu"}
+	assert.Error(ValidatePage(&page))
+}
+
+// useFixturesRoot sets the fixtures root for the test
+func useFixturesRoot(t *testing.T, root string) {
+	t.Helper()
+
+	ConfigureFixtures(config.FixturesCfg{Root: root})
+	t.Cleanup(func() {
+		ConfigureFixtures(config.FixturesCfg{})
+	})
+}
@@ -0,0 +1,159 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/rs/zerolog"
+)
+
+type followResolver struct {
+	page models.Page
+}
+
+// Resolve implements PageResolver
+func (r *followResolver) Resolve(ctx context.Context) models.RunResult {
+	ll := zerolog.Ctx(ctx).With().
+		Str("page_url", r.page.URL).
+		Logger()
+
+	if len(r.page.Follow.Hops) == 0 {
+		return makeErrorResult(r.page, fmt.Errorf("follow resolver requires at least one hop"))
+	}
+
+	targetResolver := r.page.Follow.Resolver
+	if targetResolver == "" {
+		targetResolver = "default"
+	}
+	if targetResolver == "follow" {
+		return makeErrorResult(r.page, fmt.Errorf("follow resolver can not be used as the target resolver"))
+	}
+
+	currentURL := r.page.URL
+	for idx, hop := range r.page.Follow.Hops {
+		hopPage := r.makeTargetPage(currentURL, idx == 0)
+		hopPage.Query = hop.Query
+		hopPage.XPath = hop.XPath
+		hopPage.Exclude = nil
+
+		link, res, ok := r.findLink(ctx, &hopPage, hop)
+		if !ok {
+			return res
+		}
+
+		ll.Debug().
+			Int("hop", idx).
+			Str("link", link).
+			Msg("Following the link")
+
+		currentURL = link
+	}
+
+	target := r.makeTargetPage(currentURL, false)
+	target.Resolver = targetResolver
+
+	res := NewPageResolver(target).Resolve(ctx)
+	res.Page = r.page
+
+	return res
+}
+
+// makeTargetPage creates a copy of the page for the URL,
+// the request method and body are used only for the initial page
+func (r *followResolver) makeTargetPage(pageURL string, initial bool) models.Page {
+	target := r.page
+	target.URL = pageURL
+	target.Follow = models.FollowConfig{}
+
+	if !initial {
+		target.Request = models.RequestConfig{
+			Headers: r.page.Request.Headers,
+			Cookies: r.page.Request.Cookies,
+			Timeout: r.page.Request.Timeout,
+		}
+	}
+
+	return target
+}
+
+// findLink finds the first link on the hop page matching the hop configuration,
+// if no link has been found, it returns the result to be returned by the resolver
+func (r *followResolver) findLink(
+	ctx context.Context,
+	hopPage *models.Page,
+	hop models.FollowHop,
+) (string, models.RunResult, bool) {
+	var pattern *regexp.Regexp
+	if hop.Pattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(hop.Pattern); err != nil {
+			return "", makeErrorResult(r.page, fmt.Errorf("invalid follow pattern: %w", err)), false
+		}
+	}
+
+	bodyContent, err := getContentForWebPage(ctx, hopPage)
+	if err != nil {
+		return "", makeErrorResult(r.page, err), false
+	}
+
+	nodes, err := ParseWebPageContent(ctx, hopPage, bodyContent)
+	if err != nil {
+		return "", makeParseErrorResult(r.page, err), false
+	}
+
+	baseURL, err := url.Parse(hopPage.URL)
+	if err != nil {
+		return "", makeErrorResult(r.page, err), false
+	}
+
+	for _, node := range nodes {
+		href := getAttrValue(node.Attrs, "href")
+		if href == "" {
+			href = getAttrValue(node.Attrs, "src")
+		}
+		if href == "" {
+			continue
+		}
+
+		if pattern != nil && !pattern.MatchString(href) && !pattern.MatchString(nodeText(node)) {
+			continue
+		}
+
+		link, err := baseURL.Parse(strings.TrimSpace(href))
+		if err != nil || !r.isFollowable(link) {
+			continue
+		}
+
+		return link.String(), models.RunResult{}, true
+	}
+
+	return "", makeSelectorMissResult(r.page, "follow", pageSelector(hopPage)), false
+}
+
+// isFollowable whether the link can be followed - only http(s) links,
+// file links only if the page itself is the local file (the fixtures root is enforced on read)
+func (r *followResolver) isFollowable(link *url.URL) bool {
+	switch link.Scheme {
+	case "http", "https":
+		return true
+	case "file":
+		return isFileURL(r.page.URL)
+	default:
+		return false
+	}
+}
+
+// nodeText returns the text content of the node
+func nodeText(node HTMLPageNode) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(node.Content))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(doc.Text())
+}
@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowResolverFollowsLinks(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body>
+			<a class="menu" href="/old-menu">Last week</a>
+			<a class="menu" href="/menus/week-42">This week</a>
+		</body></html>`)
+	})
+	mux.HandleFunc("/menus/week-42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body><div id="menu"><p>Goulash</p></div></body></html>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "test",
+		URL:      server.URL + "/",
+		Query:    "#menu",
+		Resolver: "follow",
+		Follow: models.FollowConfig{
+			Hops: []models.FollowHop{{Query: "a.menu", Pattern: "(?i)this week"}},
+		},
+	}
+
+	res := NewPageResolver(page).Resolve(t.Context())
+
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Equal("Goulash", res.Content)
+	assert.Equal(page.URL, res.Page.URL)
+}
+
+func TestFollowResolverSkipsLocalFileLinks(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	useFixturesRoot(t, root)
+	fixture := filepath.Join(root, "menu.html")
+	assert.NoError(os.WriteFile(fixture, []byte(`<div id="menu"><p>Fixture menu</p></div>`), 0o600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `<html><body>
+			<a class="menu" href="%s">Menu</a>
+			<iframe src="%s"></iframe>
+		</body></html>`, fileURL(fixture), fileURL(fixture))
+	}))
+	defer server.Close()
+
+	for _, resolver := range []string{"follow", "iframe"} {
+		page := models.Page{
+			CodeName: "test",
+			URL:      server.URL + "/",
+			Query:    "#menu",
+			Resolver: resolver,
+			Follow:   models.FollowConfig{Hops: []models.FollowHop{{Query: "a.menu"}}},
+		}
+
+		res := NewPageResolver(page).Resolve(t.Context())
+		assert.NotEqual(models.RunSuccess, res.Status, resolver)
+		assert.NotContains(res.Content, "Fixture menu", resolver)
+	}
+}
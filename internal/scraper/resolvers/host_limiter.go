@@ -0,0 +1,53 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// hostLimiter limits the rate of the outbound requests for each host
+type hostLimiter struct {
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+var defaultHostLimiter = &hostLimiter{
+	next: map[string]time.Time{},
+}
+
+// Wait blocks until the request to the host is allowed,
+// requests to the same host are at least interval apart
+func (l *hostLimiter) Wait(ctx context.Context, host string, interval time.Duration) error {
+	if interval <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(interval)
+	l.mu.Unlock()
+
+	return sleepContext(ctx, slot.Sub(now))
+}
+
+// sleepContext sleeps for the duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -3,8 +3,12 @@ package resolvers
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
 	"github.com/antchfx/htmlquery"
 	"github.com/pestanko/miniscrape/internal/models"
 	"github.com/rs/zerolog"
@@ -24,14 +28,15 @@ func ParseWebPageContent(
 	bodyContent []byte,
 ) (contentArray []HTMLPageNode, err error) {
 	if page.Query != "" {
-		contentArray, err = parseUsingCSSQuery(ctx, bodyContent, page.Query)
+		contentArray, err = parseUsingCSSQuery(ctx, bodyContent, page)
 	} else {
-		contentArray, err = parseUsingXPathQuery(ctx, bodyContent, page.XPath)
+		contentArray, err = parseUsingXPathQuery(ctx, bodyContent, page)
 	}
 	return
 }
 
-func parseUsingXPathQuery(ctx context.Context, content []byte, xpath string) ([]HTMLPageNode, error) {
+func parseUsingXPathQuery(ctx context.Context, content []byte, page *models.Page) ([]HTMLPageNode, error) {
+	xpath := page.XPath
 	zerolog.Ctx(ctx).Trace().
 		Str("xpath", xpath).
 		Msg("Parse using the the XPath")
@@ -45,12 +50,16 @@ func parseUsingXPathQuery(ctx context.Context, content []byte, xpath string) ([]
 		return []HTMLPageNode{}, err
 	}
 
+	preparer := newNodePreparer(root, page)
 	var result []HTMLPageNode
 
 	for _, node := range nodes {
 		if node == nil {
 			continue
 		}
+		if err := preparer.prepare(node); err != nil {
+			return []HTMLPageNode{}, err
+		}
 		htmlContent := htmlquery.OutputHTML(node, true)
 		result = append(result, HTMLPageNode{
 			Content: htmlContent,
@@ -61,7 +70,12 @@ func parseUsingXPathQuery(ctx context.Context, content []byte, xpath string) ([]
 	return result, nil
 }
 
-func parseUsingCSSQuery(ctx context.Context, bodyContent []byte, query string) ([]HTMLPageNode, error) {
+func parseUsingCSSQuery(
+	ctx context.Context,
+	bodyContent []byte,
+	page *models.Page,
+) ([]HTMLPageNode, error) {
+	query := page.Query
 	ll := zerolog.Ctx(ctx).With().Str("css_query", query).Logger()
 	ll.Trace().Msg("Parse using the the CSS query")
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(bodyContent))
@@ -69,8 +83,19 @@ func parseUsingCSSQuery(ctx context.Context, bodyContent []byte, query string) (
 		return []HTMLPageNode{}, err
 	}
 
+	preparer := newNodePreparer(doc.Nodes[0], page)
 	var content []HTMLPageNode
+	var prepareErr error
 	doc.Find(query).Each(func(idx int, selection *goquery.Selection) {
+		if prepareErr != nil {
+			return
+		}
+		for _, node := range selection.Nodes {
+			if prepareErr = preparer.prepare(node); prepareErr != nil {
+				return
+			}
+		}
+
 		htmlContent, err := selection.Html()
 		if err != nil {
 			ll.Warn().
@@ -87,6 +112,10 @@ func parseUsingCSSQuery(ctx context.Context, bodyContent []byte, query string) (
 		})
 	})
 
+	if prepareErr != nil {
+		return []HTMLPageNode{}, prepareErr
+	}
+
 	if len(content) == 0 {
 		ll.Warn().Msg("No content found")
 	}
@@ -94,6 +123,101 @@ func parseUsingCSSQuery(ctx context.Context, bodyContent []byte, query string) (
 	return content, nil
 }
 
+// nodePreparer prepares the matched nodes before their content is extracted,
+// it removes the excluded nodes and resolves the relative URLs
+type nodePreparer struct {
+	exclude []string
+	// baseURL the relative URLs are resolved against, nil - the URLs are kept
+	baseURL *url.URL
+}
+
+func newNodePreparer(doc *html.Node, page *models.Page) *nodePreparer {
+	preparer := &nodePreparer{
+		exclude: page.Exclude,
+	}
+	if !page.KeepRelativeURLs {
+		preparer.baseURL = documentBaseURL(doc, page.URL)
+	}
+
+	return preparer
+}
+
+func (p *nodePreparer) prepare(node *html.Node) error {
+	if err := removeExcludedNodes(node, p.exclude); err != nil {
+		return err
+	}
+	if p.baseURL != nil {
+		resolveNodeURLs(node, p.baseURL)
+	}
+
+	return nil
+}
+
+// removeExcludedNodes removes the nodes matching the exclude selectors from the root descendants
+func removeExcludedNodes(root *html.Node, exclude []string) error {
+	for _, selector := range exclude {
+		nodes, err := findExcludedNodes(root, selector)
+		if err != nil {
+			return err
+		}
+
+		for _, node := range nodes {
+			// the XPath may match nodes outside the root and the nodes of already removed subtrees
+			if node == root || node.Parent == nil || !isDescendantNode(node, root) {
+				continue
+			}
+			node.Parent.RemoveChild(node)
+		}
+	}
+
+	return nil
+}
+
+func findExcludedNodes(root *html.Node, selector string) ([]*html.Node, error) {
+	if isXPathSelector(selector) {
+		nodes, err := htmlquery.QueryAll(root, selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude xpath %q: %w", selector, err)
+		}
+		return nodes, nil
+	}
+
+	sel, err := cascadia.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude query %q: %w", selector, err)
+	}
+
+	return sel.MatchAll(root), nil
+}
+
+// isXPathSelector whether the selector is the XPath (ex. "//div", "./p", "(//p)[1]"), not the CSS query
+func isXPathSelector(selector string) bool {
+	return strings.HasPrefix(selector, "/") ||
+		strings.HasPrefix(selector, "./") ||
+		strings.HasPrefix(selector, "(")
+}
+
+func isDescendantNode(node, root *html.Node) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent == root {
+			return true
+		}
+	}
+	return false
+}
+
+// validateExclude checks whether the exclude selectors of the page are valid
+func validateExclude(page *models.Page) error {
+	empty := &html.Node{Type: html.DocumentNode}
+	for _, selector := range page.Exclude {
+		if _, err := findExcludedNodes(empty, selector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func getAttributesFromSelection(selection *goquery.Selection) []html.Attribute {
 	if selection == nil || len(selection.Nodes) == 0 {
 		return []html.Attribute{}
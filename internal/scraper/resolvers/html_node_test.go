@@ -0,0 +1,128 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWebPageContentExclude(t *testing.T) {
+	assert := assert.New(t)
+
+	body := []byte(`<html><body>
+<div class="cookies">We use cookies</div>
+<div class="menu">
+	<script>track()</script>
+	<p>Goulash 159</p>
+	<div class="share">Share on Facebook</div>
+	<p class="allergens">Allergens: 1 - gluten</p>
+</div>
+</body></html>`)
+
+	tests := []struct {
+		name string
+		page models.Page
+	}{
+		{
+			name: "css query",
+			page: models.Page{Query: ".menu", Exclude: []string{"script", ".share", "p.allergens"}},
+		},
+		{
+			name: "xpath",
+			page: models.Page{
+				XPath:   "//div[@class='menu']",
+				Exclude: []string{".//script", "//div[@class='share']", "(.//p)[2]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		nodes, err := ParseWebPageContent(t.Context(), &tt.page, body)
+		assert.NoError(err, tt.name)
+		if !assert.Len(nodes, 1, tt.name) {
+			continue
+		}
+
+		assert.Contains(nodes[0].Content, "Goulash 159", tt.name)
+		for _, excluded := range []string{"track()", "Share on Facebook", "Allergens"} {
+			assert.NotContains(nodes[0].Content, excluded, tt.name)
+		}
+	}
+
+	assert.NoError(validateExclude(&models.Page{Exclude: []string{"div.share > a", "//script"}}))
+	assert.Error(validateExclude(&models.Page{Exclude: []string{"div[class="}}))
+	assert.Error(validateExclude(&models.Page{Exclude: []string{"//div[@class="}}))
+}
+
+func TestParseWebPageContentSourceExclude(t *testing.T) {
+	assert := assert.New(t)
+
+	body := []byte(`<html><body><div class="menu"><p>Goulash 159</p><p>Lentil soup 45</p><script>track()</script></div></body></html>`)
+
+	page := models.Page{
+		XPath:   "//div[@class='menu']",
+		Exclude: []string{"(.//p)[2]"},
+		Sources: []models.PageSource{
+			{Name: "web"},
+			{Name: "css", Query: ".menu"},
+			{Name: "css without scripts", Query: ".menu", Exclude: []string{"script"}},
+		},
+	}
+
+	tests := []struct {
+		source   models.PageSource
+		contains []string
+		missing  []string
+	}{
+		{source: page.Sources[0], contains: []string{"Goulash"}, missing: []string{"Lentil soup"}},
+		{source: page.Sources[1], contains: []string{"Goulash", "Lentil soup", "track()"}},
+		{source: page.Sources[2], contains: []string{"Goulash", "Lentil soup"}, missing: []string{"track()"}},
+	}
+
+	for _, tt := range tests {
+		srcPage := page.WithSource(tt.source)
+		nodes, err := ParseWebPageContent(t.Context(), &srcPage, body)
+		assert.NoError(err, tt.source.Name)
+		if !assert.Len(nodes, 1, tt.source.Name) {
+			continue
+		}
+
+		for _, want := range tt.contains {
+			assert.Contains(nodes[0].Content, want, tt.source.Name)
+		}
+		for _, notWant := range tt.missing {
+			assert.NotContains(nodes[0].Content, notWant, tt.source.Name)
+		}
+	}
+}
+
+func TestParseWebPageContentResolvesURLs(t *testing.T) {
+	assert := assert.New(t)
+
+	body := []byte(`<html><head><base href="/lunch/"></head><body><div class="menu">
+<a href="menu.pdf">Menu</a> <a href="#today">Today</a> <a href="mailto:info@bistro.example">Mail</a>
+<img src="/img/soup.png" srcset="soup.png 1x, soup-2x.png 2x">
+<a href="https://other.example/page">Other</a>
+</div></body></html>`)
+
+	page := models.Page{URL: "https://bistro.example/index.html", Query: ".menu"}
+	nodes, err := ParseWebPageContent(t.Context(), &page, body)
+	assert.NoError(err)
+	if assert.Len(nodes, 1) {
+		content := nodes[0].Content
+		assert.Contains(content, `href="https://bistro.example/lunch/menu.pdf"`)
+		assert.Contains(content, `href="#today"`)
+		assert.Contains(content, `href="mailto:info@bistro.example"`)
+		assert.Contains(content, `src="https://bistro.example/img/soup.png"`)
+		assert.Contains(content, `srcset="https://bistro.example/lunch/soup.png 1x, https://bistro.example/lunch/soup-2x.png 2x"`)
+		assert.Contains(content, `href="https://other.example/page"`)
+	}
+
+	page = models.Page{URL: "https://bistro.example/index.html", XPath: "//div", KeepRelativeURLs: true}
+	nodes, err = ParseWebPageContent(t.Context(), &page, body)
+	assert.NoError(err)
+	if assert.Len(nodes, 1) {
+		assert.Contains(nodes[0].Content, `href="menu.pdf"`)
+	}
+}
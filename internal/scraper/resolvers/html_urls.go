@@ -0,0 +1,92 @@
+package resolvers
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// urlAttrs attributes containing a single URL
+var urlAttrs = map[string]bool{
+	"href":   true,
+	"src":    true,
+	"poster": true,
+	"action": true,
+	"data":   true,
+}
+
+var baseSelector = cascadia.MustCompile("base[href]")
+
+// documentBaseURL returns the URL the relative URLs of the document are resolved against -
+// the <base> of the document resolved against the page URL or the page URL itself,
+// nil - there is no http(s) base URL (ex. the fixture pages)
+func documentBaseURL(doc *html.Node, pageURL string) *url.URL {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	if node := baseSelector.MatchFirst(doc); node != nil {
+		if href, err := base.Parse(strings.TrimSpace(getAttrValue(node.Attr, "href"))); err == nil {
+			base = href
+		}
+	}
+
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil
+	}
+
+	return base
+}
+
+// resolveNodeURLs resolves the relative URLs of the node and all its descendants against the base URL
+func resolveNodeURLs(node *html.Node, base *url.URL) {
+	if node.Type == html.ElementNode {
+		for idx, attr := range node.Attr {
+			switch {
+			case attr.Namespace != "":
+			case urlAttrs[attr.Key]:
+				node.Attr[idx].Val = resolveURL(base, attr.Val)
+			case attr.Key == "srcset":
+				node.Attr[idx].Val = resolveSrcSet(base, attr.Val)
+			}
+		}
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		resolveNodeURLs(child, base)
+	}
+}
+
+// resolveURL resolves the URL against the base, the fragments and
+// the URLs which can not be parsed are kept
+func resolveURL(base *url.URL, value string) string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return value
+	}
+
+	resolved, err := base.Parse(trimmed)
+	if err != nil {
+		return value
+	}
+
+	return resolved.String()
+}
+
+// resolveSrcSet resolves the URLs of the image candidates (ex. "img.png 1x, img-2x.png 2x")
+func resolveSrcSet(base *url.URL, value string) string {
+	candidates := strings.Split(value, ",")
+	for idx, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = resolveURL(base, fields[0])
+		candidates[idx] = strings.Join(fields, " ")
+	}
+
+	return strings.Join(candidates, ", ")
+}
@@ -0,0 +1,51 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIframeResolverScrapesEmbeddedDocument(t *testing.T) {
+	assert := assert.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body>
+			<iframe class="ads" src="/ads"></iframe>
+			<iframe class="menu" src="embed/menu?id=42"></iframe>
+		</body></html>`)
+	})
+	mux.HandleFunc("/embed/menu", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `<html><body><div id="menu"><p>Menu %s</p></div></body></html>`, r.URL.Query().Get("id"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "test",
+		URL:      server.URL + "/",
+		Query:    "#menu",
+		Resolver: "iframe",
+		Iframe:   models.IframeConfig{Query: "iframe.menu"},
+	}
+
+	assert.NoError(ValidatePage(&page))
+
+	res := NewPageResolver(page).Resolve(t.Context())
+
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Equal("Menu 42", res.Content)
+	assert.Equal(page.URL, res.Page.URL)
+
+	page.Iframe = models.IframeConfig{URLOnly: true}
+	res = NewPageResolver(page).Resolve(t.Context())
+
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Equal(page.URL, res.Content)
+	assert.Equal("iframe", res.Kind)
+}
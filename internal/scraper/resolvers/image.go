@@ -2,12 +2,38 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
+	"mime"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
 
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pestanko/miniscrape/internal/cache"
 	"github.com/pestanko/miniscrape/internal/models"
 	"github.com/rs/zerolog"
+	"golang.org/x/net/html"
 )
 
+// StoredImagesPath path of the API endpoint serving the images stored in the cache
+const StoredImagesPath = "/api/v1/images"
+
+// storedImageTypes file extensions and content types of the images which can be stored,
+// SVG images are not stored as they can contain scripts
+var storedImageTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".avif": "image/avif",
+}
+
+// imageSourceAttrs attributes with the image source, ordered by the priority,
+// lazy loaded images have a placeholder in the src attribute
+var imageSourceAttrs = []string{"data-src", "data-lazy-src", "srcset", "data-srcset", "src"}
+
 type imageResolver struct {
 	page   models.Page
 	client http.Client
@@ -42,21 +68,185 @@ func (r *imageResolver) Resolve(ctx context.Context) models.RunResult {
 			Str("pageUrl", r.page.URL).
 			Msg("Content parsing failed")
 
-		return makeErrorResult(r.page, err)
+		return makeParseErrorResult(r.page, err)
 	}
 
-	if len(contentArray) == 0 {
+	images := r.findImages(contentArray)
+	if len(images) == 0 {
 		ll.Warn().Msg("No content found")
-		return makeEmptyResult(r.page, "img")
+		return makeSelectorMissResult(r.page, "img", pageSelector(&r.page))
 	}
 
-	// Pick the first image
-	content := getAttrValue(contentArray[0].Attrs, "src")
+	if r.page.Image.Store {
+		images = r.storeImages(ctx, images)
+	}
 
 	return models.RunResult{
 		Page:    r.page,
-		Content: content,
+		Content: strings.Join(images, "\n"),
 		Status:  models.RunSuccess,
 		Kind:    "img",
 	}
 }
+
+// findImages returns absolute URLs of all images in the nodes,
+// the node is either an image itself or it contains the images
+func (r *imageResolver) findImages(nodes []HTMLPageNode) []string {
+	baseURL, err := url.Parse(r.page.URL)
+	if err != nil {
+		return nil
+	}
+
+	var images []string
+	addImage := func(attrs []html.Attribute) {
+		src := getImageSource(attrs)
+		if src == "" {
+			return
+		}
+		imgURL, err := baseURL.Parse(src)
+		if err != nil {
+			return
+		}
+		images = append(images, imgURL.String())
+	}
+
+	for _, node := range nodes {
+		if getImageSource(node.Attrs) != "" {
+			addImage(node.Attrs)
+			continue
+		}
+
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(node.Content))
+		if err != nil {
+			continue
+		}
+		doc.Find("img").Each(func(_ int, selection *goquery.Selection) {
+			addImage(getAttributesFromSelection(selection))
+		})
+	}
+
+	if r.page.Image.Limit > 0 && len(images) > r.page.Image.Limit {
+		images = images[:r.page.Image.Limit]
+	}
+
+	return images
+}
+
+// storeImages downloads the images to the cache, returns the URLs of the stored images,
+// if the image can not be stored, the original URL is used
+func (r *imageResolver) storeImages(ctx context.Context, images []string) []string {
+	ll := zerolog.Ctx(ctx)
+
+	rc := responseCacheFromContext(ctx)
+	item := cache.Item{CachePolicy: r.page.CachePolicy}
+	if rc == nil || !item.IsCacheable() {
+		ll.Warn().Msg("Images can not be stored - the page is not cached")
+		return images
+	}
+
+	result := make([]string, len(images))
+	for idx, imgURL := range images {
+		result[idx] = imgURL
+
+		imgPage := r.page
+		imgPage.URL = imgURL
+		imgPage.Request = models.RequestConfig{
+			Headers: r.page.Request.Headers,
+			Cookies: r.page.Request.Cookies,
+			Timeout: r.page.Request.Timeout,
+		}
+
+		// the image responses must not replace the stored validators of the page
+		res, err := fetchPage(withoutResponseCache(ctx), &imgPage)
+		if err != nil {
+			ll.Warn().Err(err).Str("image_url", imgURL).Msg("Unable to download the image")
+			continue
+		}
+
+		ext, ok := storedImageExtension(res.Header.Get("Content-Type"))
+		if !ok {
+			ll.Warn().
+				Str("image_url", imgURL).
+				Str("content_type", res.Header.Get("Content-Type")).
+				Msg("Unable to store the image - unsupported content type")
+			continue
+		}
+
+		item.Namespace = rc.namespace
+		item.FileName = "image-" + strconv.Itoa(idx) + ext
+		if err := rc.cache.Store(item, res.Body); err != nil {
+			ll.Warn().Err(err).Str("image_url", imgURL).Msg("Unable to store the image")
+			continue
+		}
+
+		result[idx] = fmt.Sprintf("%s/%s/%s", StoredImagesPath, item.Namespace.Path(), item.FileName)
+	}
+
+	return result
+}
+
+// getImageSource returns the image source based on the attributes priority
+func getImageSource(attrs []html.Attribute) string {
+	for _, name := range imageSourceAttrs {
+		value := strings.TrimSpace(getAttrValue(attrs, name))
+		if strings.HasSuffix(name, "srcset") {
+			value = largestSrcSetCandidate(value)
+		}
+		if value != "" && !strings.HasPrefix(value, "data:") {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// largestSrcSetCandidate returns the URL of the largest candidate in the srcset
+// see: https://developer.mozilla.org/en-US/docs/Web/HTML/Element/img#srcset
+func largestSrcSetCandidate(srcset string) string {
+	var result string
+	var maxSize float64
+	for _, candidate := range strings.Split(srcset, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+
+		size := 1.0
+		if len(fields) > 1 {
+			descriptor := strings.TrimRight(fields[1], "wx")
+			if value, err := strconv.ParseFloat(descriptor, 64); err == nil {
+				size = value
+			}
+		}
+
+		if result == "" || size > maxSize {
+			result, maxSize = fields[0], size
+		}
+	}
+
+	return result
+}
+
+// storedImageExtension returns the file extension of the image content type,
+// false - the content is not the image which can be stored
+func storedImageExtension(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	for ext, imageType := range storedImageTypes {
+		if imageType == mediaType {
+			return ext, true
+		}
+	}
+
+	return "", false
+}
+
+// StoredImageContentType returns the content type of the stored image based on its file name,
+// false - the file is not the stored image
+func StoredImageContentType(fileName string) (string, bool) {
+	contentType, ok := storedImageTypes[strings.ToLower(path.Ext(fileName))]
+	return contentType, ok
+}
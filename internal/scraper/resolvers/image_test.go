@@ -0,0 +1,74 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/cache"
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func TestImageResolverFindImagesAbsoluteURLs(t *testing.T) {
+	assert := assert.New(t)
+
+	resolver := &imageResolver{page: models.Page{URL: "https://example.com/menu/today.html"}}
+
+	images := resolver.findImages([]HTMLPageNode{
+		{Attrs: []html.Attribute{{Key: "src", Val: "/img/monday.jpg"}}},
+		{Attrs: []html.Attribute{
+			{Key: "src", Val: "data:image/gif;base64,R0lGODlh"},
+			{Key: "data-src", Val: "tuesday.jpg"},
+		}},
+		{Content: `<p><img srcset="small.png 480w, large.png 1080w" src="small.png"></p>`},
+	})
+
+	assert.Equal([]string{
+		"https://example.com/img/monday.jpg",
+		"https://example.com/menu/tuesday.jpg",
+		"https://example.com/menu/large.png",
+	}, images)
+}
+
+func TestImageResolverStoresOnlyImages(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"`+r.URL.Path+`"`)
+		switch r.URL.Path {
+		case "/menu.png":
+			w.Header().Set("Content-Type", "image/png")
+		case "/menu.svg":
+			w.Header().Set("Content-Type", "image/svg+xml")
+		default:
+			w.Header().Set("Content-Type", "text/html")
+		}
+		_, _ = fmt.Fprint(w, "content of "+r.URL.Path)
+	}))
+	defer server.Close()
+
+	c := cache.NewCache(config.CacheCfg{Enabled: true, Root: t.TempDir()}, time.Now())
+	nm := cache.NewNamespace("food", "bistro")
+	ctx := withResponseCache(t.Context(), c, nm)
+
+	resolver := &imageResolver{page: models.Page{CodeName: "bistro", Category: "food"}}
+	images := []string{server.URL + "/menu.png", server.URL + "/menu.svg", server.URL + "/menu.jpg"}
+	stored := resolver.storeImages(ctx, images)
+
+	assert.Equal([]string{StoredImagesPath + "/food/bistro/image-0.png", images[1], images[2]}, stored)
+	assert.Equal("content of /menu.png", string(c.GetContent(cache.Item{Namespace: nm, FileName: "image-0.png"})))
+
+	_, ok := c.GetResponse(nm, images[0])
+	assert.False(ok, "the image responses must not be stored as the page responses")
+
+	contentType, ok := StoredImageContentType("image-0.png")
+	assert.True(ok)
+	assert.Equal("image/png", contentType)
+	_, ok = StoredImageContentType("image-1.svg")
+	assert.False(ok)
+}
@@ -0,0 +1,143 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/filters"
+	"github.com/rs/zerolog"
+	"github.com/tidwall/gjson"
+)
+
+type jsonResolver struct {
+	page    models.Page
+	filters []func(*models.Page) filters.PageFilter
+}
+
+// Resolve implements PageResolver
+func (r *jsonResolver) Resolve(ctx context.Context) models.RunResult {
+	ll := zerolog.Ctx(ctx).With().
+		Str("json_path", r.page.JSON.Path).
+		Logger()
+
+	bodyContent, err := getContentForWebPage(ctx, &r.page)
+	if err != nil {
+		return makeErrorResult(r.page, err)
+	}
+
+	if !gjson.ValidBytes(bodyContent) {
+		ll.Warn().Msg("Response is not a valid JSON")
+		return makeParseErrorResult(r.page, fmt.Errorf("invalid json content"))
+	}
+
+	selected := gjson.ParseBytes(bodyContent)
+	if r.page.JSON.Path != "" {
+		selected = selected.Get(r.page.JSON.Path)
+	}
+
+	if !selected.Exists() {
+		ll.Warn().Msg("No content found")
+		return makeSelectorMissResult(r.page, "json", r.page.JSON.Path)
+	}
+
+	content, err := r.render(selected)
+	if err != nil {
+		ll.Warn().Err(err).Msg("Unable to render the json content")
+		return makeParseErrorResult(r.page, err)
+	}
+
+	content = applyFilters(ctx, &r.page, r.filters, content)
+	if content == "" {
+		ll.Warn().Msg("Content resolved but the content is empty")
+		return makeEmptyResult(r.page, "json")
+	}
+
+	ll.Debug().Msg("Content resolved")
+
+	return models.RunResult{
+		Page:    r.page,
+		Status:  models.RunSuccess,
+		Content: content,
+		Kind:    "json",
+	}
+}
+
+// render converts the selected json value into the markdown
+func (r *jsonResolver) render(value gjson.Result) (string, error) {
+	var sb strings.Builder
+	if err := r.renderValue(&sb, value); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+func (r *jsonResolver) renderValue(sb *strings.Builder, value gjson.Result) error {
+	switch {
+	case value.IsArray():
+		for _, item := range value.Array() {
+			if item.IsObject() {
+				sb.WriteString("- ")
+			}
+			if err := r.renderValue(sb, item); err != nil {
+				return err
+			}
+		}
+	case value.IsObject():
+		sb.WriteString(strings.Join(r.objectValues(value), " | "))
+		sb.WriteString("\n")
+	default:
+		text, err := r.renderString(value.String())
+		if err != nil {
+			return err
+		}
+		sb.WriteString(text)
+		sb.WriteString("\n")
+	}
+
+	return nil
+}
+
+// objectValues returns the non-empty values of configured fields,
+// if no fields are configured, all fields are used ordered by the key
+func (r *jsonResolver) objectValues(value gjson.Result) []string {
+	fields := r.page.JSON.Fields
+	if len(fields) == 0 {
+		for key := range value.Map() {
+			fields = append(fields, key)
+		}
+		sort.Strings(fields)
+	}
+
+	var values []string
+	for _, field := range fields {
+		fieldValue := value.Get(field)
+		if !fieldValue.Exists() || fieldValue.IsObject() || fieldValue.IsArray() {
+			continue
+		}
+		text, err := r.renderString(fieldValue.String())
+		if err != nil || text == "" {
+			continue
+		}
+		values = append(values, strings.ReplaceAll(text, "\n", " "))
+	}
+
+	return values
+}
+
+func (r *jsonResolver) renderString(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !r.page.JSON.HTML || value == "" {
+		return value, nil
+	}
+
+	md, err := filters.NewHTMLToMdConverter(&r.page).Filter(value)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(md), nil
+}
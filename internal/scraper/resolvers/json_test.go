@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestJSONResolverRenderArrayOfObjects(t *testing.T) {
+	assert := assert.New(t)
+
+	resolver := &jsonResolver{
+		page: models.Page{
+			JSON: models.JSONConfig{
+				Path:   "menu.items",
+				Fields: []string{"name", "price"},
+			},
+		},
+	}
+
+	value := gjson.Get(`{"menu": {"items": [
+		{"name": "Soup", "price": 45, "id": 1},
+		{"name": "Schnitzel", "price": 159, "id": 2}
+	]}}`, resolver.page.JSON.Path)
+
+	content, err := resolver.render(value)
+
+	assert.NoError(err)
+	assert.Equal("- Soup | 45\n- Schnitzel | 159\n", content)
+}
+
+func TestJSONResolverRenderHTMLString(t *testing.T) {
+	assert := assert.New(t)
+
+	resolver := &jsonResolver{
+		page: models.Page{
+			JSON: models.JSONConfig{HTML: true},
+		},
+	}
+
+	content, err := resolver.render(gjson.Parse(`"<p><strong>Monday</strong></p>"`))
+
+	assert.NoError(err)
+	assert.Equal("**Monday**\n", content)
+}
@@ -0,0 +1,295 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/filters"
+	"github.com/pestanko/miniscrape/internal/scraper/menu"
+	"github.com/rs/zerolog"
+	"github.com/tidwall/gjson"
+)
+
+// jsonLDResolver resolves the schema.org Menu and Event data
+// embedded in the page as the application/ld+json scripts
+// see: https://schema.org/Menu, https://schema.org/Event
+type jsonLDResolver struct {
+	page    models.Page
+	filters []func(*models.Page) filters.PageFilter
+}
+
+// jsonLDEvent schema.org Event
+type jsonLDEvent struct {
+	Name        string
+	StartDate   string
+	Description string
+	Location    string
+	URL         string
+}
+
+// jsonLDSection schema.org MenuSection
+type jsonLDSection struct {
+	Name  string
+	Items []jsonLDItem
+}
+
+// jsonLDItem schema.org MenuItem
+type jsonLDItem struct {
+	Dish models.Dish
+	// Description of the dish, the structured menu has no place for it
+	Description string
+}
+
+// jsonLDData data collected from all scripts of the page
+type jsonLDData struct {
+	sections []jsonLDSection
+	events   []jsonLDEvent
+}
+
+// menu returns the structured menu of the collected sections
+func (d *jsonLDData) menu() *models.Menu {
+	menu := &models.Menu{}
+	for _, section := range d.sections {
+		menuSection := models.MenuSection{Name: section.Name}
+		for _, item := range section.Items {
+			menuSection.Dishes = append(menuSection.Dishes, item.Dish)
+		}
+		menu.Sections = append(menu.Sections, menuSection)
+	}
+	return menu
+}
+
+// Resolve implements PageResolver
+func (r *jsonLDResolver) Resolve(ctx context.Context) models.RunResult {
+	ll := zerolog.Ctx(ctx).With().
+		Str("page_url", r.page.URL).
+		Logger()
+
+	bodyContent, err := getContentForWebPage(ctx, &r.page)
+	if err != nil {
+		return makeErrorResult(r.page, err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(bodyContent))
+	if err != nil {
+		return makeParseErrorResult(r.page, err)
+	}
+
+	data := jsonLDData{}
+	doc.Find(`script[type="application/ld+json"]`).Each(func(idx int, selection *goquery.Selection) {
+		raw := strings.TrimSpace(selection.Text())
+		if !gjson.Valid(raw) {
+			ll.Warn().Int("script", idx).Msg("Invalid JSON-LD script")
+			return
+		}
+		r.collect(gjson.Parse(raw), &data)
+	})
+
+	menu := data.menu()
+	if menu.IsEmpty() && len(data.events) == 0 {
+		ll.Warn().Msg("No schema.org menu or event found")
+		return makeSelectorMissResult(r.page, "jsonld", `script[type="application/ld+json"]`)
+	}
+
+	content := applyFilters(ctx, &r.page, r.filters, renderJSONLD(&data))
+	if content == "" {
+		ll.Warn().Msg("Content resolved but the content is empty")
+		return makeEmptyResult(r.page, "jsonld")
+	}
+
+	res := models.RunResult{
+		Page:    r.page,
+		Status:  models.RunSuccess,
+		Content: content,
+		Kind:    "jsonld",
+	}
+	if !menu.IsEmpty() {
+		res.Menu = menu
+	}
+
+	return res
+}
+
+// collect walks the JSON-LD value and collects the menus and events
+func (r *jsonLDResolver) collect(value gjson.Result, data *jsonLDData) {
+	if value.IsArray() {
+		for _, item := range value.Array() {
+			r.collect(item, data)
+		}
+		return
+	}
+
+	if !value.IsObject() {
+		return
+	}
+
+	if graph := value.Get("@graph"); graph.Exists() {
+		r.collect(graph, data)
+	}
+
+	switch {
+	case hasJSONLDType(value, "Menu"):
+		r.collectMenu(value, data)
+	case hasJSONLDType(value, "MenuSection"):
+		r.collectSection(value, data)
+	case hasJSONLDType(value, "Event"):
+		data.events = append(data.events, jsonLDEvent{
+			Name:        value.Get("name").String(),
+			StartDate:   value.Get("startDate").String(),
+			Description: value.Get("description").String(),
+			Location:    value.Get("location.name").String(),
+			URL:         value.Get("url").String(),
+		})
+	default:
+		// ex. Restaurant with the embedded menu
+		r.collect(value.Get("hasMenu"), data)
+	}
+}
+
+func (r *jsonLDResolver) collectMenu(menu gjson.Result, data *jsonLDData) {
+	if items := menu.Get("hasMenuItem"); items.Exists() {
+		data.sections = append(data.sections, jsonLDSection{
+			Name:  strings.TrimSpace(menu.Get("name").String()),
+			Items: r.makeItems(items),
+		})
+	}
+
+	forEachJSONLD(menu.Get("hasMenuSection"), func(section gjson.Result) {
+		r.collectSection(section, data)
+	})
+}
+
+func (r *jsonLDResolver) collectSection(section gjson.Result, data *jsonLDData) {
+	data.sections = append(data.sections, jsonLDSection{
+		Name:  strings.TrimSpace(section.Get("name").String()),
+		Items: r.makeItems(section.Get("hasMenuItem")),
+	})
+
+	forEachJSONLD(section.Get("hasMenuSection"), func(subSection gjson.Result) {
+		r.collectSection(subSection, data)
+	})
+}
+
+func (r *jsonLDResolver) makeItems(items gjson.Result) []jsonLDItem {
+	var result []jsonLDItem
+	forEachJSONLD(items, func(item gjson.Result) {
+		name := strings.TrimSpace(item.Get("name").String())
+		if name == "" {
+			return
+		}
+
+		offer := item.Get("offers")
+		if offer.IsArray() {
+			offer = offer.Get("0")
+		}
+
+		dish := models.Dish{
+			Name:       name,
+			Vegetarian: isVegetarianDiet(item.Get("suitableForDiet")),
+		}
+		price, currency, ok := menu.ParsePrice(
+			offer.Get("price").String(), offer.Get("priceCurrency").String(), r.page.Menu.Currency)
+		if ok && price != 0 {
+			dish.Price = price
+			dish.Currency = currency
+		}
+
+		result = append(result, jsonLDItem{
+			Dish:        dish,
+			Description: strings.TrimSpace(item.Get("description").String()),
+		})
+	})
+
+	return result
+}
+
+// renderJSONLD renders the collected data as HTML, so it can go through the html filters
+func renderJSONLD(data *jsonLDData) string {
+	var sb strings.Builder
+	for _, section := range data.sections {
+		if len(section.Items) == 0 {
+			continue
+		}
+		if section.Name != "" {
+			fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(section.Name))
+		}
+		sb.WriteString("<ul>\n")
+		for _, item := range section.Items {
+			dish := &item.Dish
+			fmt.Fprintf(&sb, "<li>%s", html.EscapeString(dish.Name))
+			if item.Description != "" {
+				fmt.Fprintf(&sb, " - %s", html.EscapeString(item.Description))
+			}
+			if dish.Price != 0 {
+				fmt.Fprintf(&sb, " %s", html.EscapeString(
+					strings.TrimSpace(strconv.FormatFloat(dish.Price, 'f', -1, 64)+" "+dish.Currency)))
+			}
+			sb.WriteString("</li>\n")
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	for _, event := range data.events {
+		fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(event.Name))
+		if event.StartDate != "" {
+			fmt.Fprintf(&sb, "<p><em>%s</em></p>\n", html.EscapeString(event.StartDate))
+		}
+		if event.Location != "" {
+			fmt.Fprintf(&sb, "<p>%s</p>\n", html.EscapeString(event.Location))
+		}
+		if event.Description != "" {
+			fmt.Fprintf(&sb, "<div>%s</div>\n", event.Description)
+		}
+		if event.URL != "" {
+			fmt.Fprintf(&sb, "<p><a href=\"%s\">%s</a></p>\n",
+				html.EscapeString(event.URL), html.EscapeString(event.URL))
+		}
+	}
+
+	return sb.String()
+}
+
+// hasJSONLDType whether the value is of the schema.org type,
+// subtypes of the Event (ex. ScreeningEvent) are considered events
+func hasJSONLDType(value gjson.Result, typeName string) bool {
+	matches := false
+	forEachJSONLD(value.Get("@type"), func(item gjson.Result) {
+		name := strings.TrimPrefix(strings.TrimPrefix(item.String(), "https://schema.org/"), "http://schema.org/")
+		if name == typeName || (typeName == "Event" && strings.HasSuffix(name, "Event")) {
+			matches = true
+		}
+	})
+	return matches
+}
+
+// isVegetarianDiet whether the schema.org diet is vegetarian or vegan
+func isVegetarianDiet(diet gjson.Result) bool {
+	vegetarian := false
+	forEachJSONLD(diet, func(item gjson.Result) {
+		name := item.String()
+		if strings.HasSuffix(name, "VegetarianDiet") || strings.HasSuffix(name, "VeganDiet") {
+			vegetarian = true
+		}
+	})
+	return vegetarian
+}
+
+// forEachJSONLD calls fn for each item, JSON-LD values can be either single values or arrays
+func forEachJSONLD(value gjson.Result, fn func(gjson.Result)) {
+	if !value.Exists() {
+		return
+	}
+	if !value.IsArray() {
+		fn(value)
+		return
+	}
+	for _, item := range value.Array() {
+		fn(item)
+	}
+}
@@ -0,0 +1,104 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const testJSONLDPage = `<html><head>
+<script type="application/ld+json">
+{
+	"@context": "https://schema.org",
+	"@graph": [
+		{"@type": "WebSite", "name": "Bistro"},
+		{
+			"@type": "Restaurant",
+			"name": "Bistro",
+			"hasMenu": {
+				"@type": "Menu",
+				"hasMenuSection": [
+					{
+						"@type": "MenuSection",
+						"name": "Soups",
+						"hasMenuItem": {
+							"@type": "MenuItem",
+							"name": "Lentil soup",
+							"offers": {"@type": "Offer", "price": "45", "priceCurrency": "CZK"},
+							"suitableForDiet": "https://schema.org/VegetarianDiet"
+						}
+					},
+					{
+						"@type": "MenuSection",
+						"name": "Main courses",
+						"hasMenuItem": [
+							{"@type": "MenuItem", "name": "Goulash", "description": "with dumplings", "offers": {"price": 159}},
+							{"@type": "MenuItem", "name": "Schnitzel", "offers": [{"price": "189,50"}]},
+							{"@type": "MenuItem", "name": "Steak", "offers": {"price": "349 Kč"}}
+						]
+					}
+				]
+			}
+		}
+	]
+}
+</script>
+<script type="application/ld+json">{"@type": "ScreeningEvent", "name": "Dune", "startDate": "2026-10-18T20:00", "location": {"name": "Hall 1"}}</script>
+<script type="application/ld+json">{ invalid </script>
+</head><body><div id="menu">Fragile content</div></body></html>`
+
+func TestJSONLDResolver(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, testJSONLDPage)
+	}))
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "bistro",
+		URL:      server.URL,
+		Resolver: "jsonld",
+		Menu:     models.MenuConfig{Currency: "Kč"},
+	}
+
+	assert.NoError(ValidatePage(&page))
+
+	res := NewPageResolver(page).Resolve(t.Context())
+
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Contains(res.Content, "Lentil soup 45 CZK")
+	assert.Contains(res.Content, "Goulash - with dumplings 159 CZK")
+	assert.Contains(res.Content, "Schnitzel 189.5 CZK")
+	assert.Contains(res.Content, "Steak 349 CZK")
+	assert.Contains(res.Content, "Dune")
+	assert.Contains(res.Content, "Hall 1")
+	assert.NotContains(res.Content, "Fragile content")
+
+	assert.Equal(&models.Menu{Sections: []models.MenuSection{
+		{Name: "Soups", Dishes: []models.Dish{{Name: "Lentil soup", Price: 45, Currency: "CZK", Vegetarian: true}}},
+		{Name: "Main courses", Dishes: []models.Dish{
+			{Name: "Goulash", Price: 159, Currency: "CZK"},
+			{Name: "Schnitzel", Price: 189.5, Currency: "CZK"},
+			{Name: "Steak", Price: 349, Currency: "CZK"},
+		}},
+	}}, res.Menu)
+}
+
+func TestJSONLDResolverWithoutData(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<html><body><div id="menu">Menu</div></body></html>`)
+	}))
+	defer server.Close()
+
+	res := NewPageResolver(models.Page{URL: server.URL, Resolver: "jsonld"}).Resolve(t.Context())
+
+	assert.Equal(models.RunEmpty, res.Status)
+	assert.Equal(models.ErrKindSelectorMiss, res.Error.Kind)
+}
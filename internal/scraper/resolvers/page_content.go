@@ -1,13 +1,12 @@
 package resolvers
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
-	"math/rand"
 	"net/http"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -30,9 +29,15 @@ var userAgents = []string{
 	`Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/47.0.2526.111 Safari/537.36`,
 }
 
+const defaultCommandTimeout = 30 * time.Second
+
+// defaultRequestTimeout timeout of the page request if the page does not set its own
+const defaultRequestTimeout = 30 * time.Second
+
+// httpClient client for the page requests, it has no timeout on its own,
+// each request is limited by its context deadline (see fetchPageOnce)
 var httpClient = http.Client{
-	Timeout: 30 * time.Second,
-	Transport: otelhttp.NewTransport(http.DefaultTransport,
+	Transport: otelhttp.NewTransport(defaultCassetteTransport,
 		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
 			return fmt.Sprintf("HTTP %s %s%s", r.Method, r.URL.Host, r.URL.Path)
 		}),
@@ -61,6 +66,16 @@ func (r *pageContentResolver) Resolve(ctx context.Context) models.RunResult {
 
 	ll := zerolog.Ctx(ctx)
 
+	if content, ok := reusableContent(ctx, &r.page); ok {
+		ll.Debug().Msg("Content not modified - skipping parsing")
+		return models.RunResult{
+			Page:    r.page,
+			Status:  models.RunSuccess,
+			Content: content,
+			Kind:    "content",
+		}
+	}
+
 	ll.Trace().Bytes("body", bodyContent).Msg("page body")
 
 	contentArray, err := ParseWebPageContent(ctx, &r.page, bodyContent)
@@ -69,15 +84,17 @@ func (r *pageContentResolver) Resolve(ctx context.Context) models.RunResult {
 			Err(err).
 			Str("url", r.page.URL).
 			Msg("Content parsing failed")
-		return makeErrorResult(r.page, err)
+		return makeParseErrorResult(r.page, err)
 	}
 
 	if len(contentArray) == 0 {
-		return makeEmptyResult(r.page, "content")
+		return makeSelectorMissResult(r.page, "content", pageSelector(&r.page))
 	}
 
 	content := concatContent(contentArray)
-	content = r.applyFilters(ctx, content)
+	content = applyFilters(ctx, &r.page, r.filters, content)
+
+	storeContent(ctx, &r.page, content)
 
 	var status = models.RunSuccess
 	if content == "" {
@@ -97,18 +114,41 @@ func (r *pageContentResolver) Resolve(ctx context.Context) models.RunResult {
 	}
 }
 
-func getContentForWebPage(ctx context.Context, page *models.Page) (bodyContent []byte, err error) {
+func getContentForWebPage(ctx context.Context, page *models.Page) ([]byte, error) {
+	bodyContent, contentType, err := getTypedContentForWebPage(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformEncoding(ctx, page, bodyContent, contentType), nil
+}
+
+// getRawContentForWebPage returns the content as it was received,
+// without any encoding transformation (useful for binary content)
+func getRawContentForWebPage(ctx context.Context, page *models.Page) ([]byte, error) {
+	bodyContent, _, err := getTypedContentForWebPage(ctx, page)
+	return bodyContent, err
+}
+
+// getTypedContentForWebPage returns the raw content with its content type,
+// the content type is empty if it is not known
+func getTypedContentForWebPage(ctx context.Context, page *models.Page) ([]byte, string, error) {
 	if page.Command.Content.Name != "" {
-		bodyContent, err = getContentByCommand(ctx, page)
-	} else {
-		bodyContent, err = getContentByRequest(ctx, page)
+		bodyContent, err := getContentByCommand(ctx, page)
+		return bodyContent, "", err
 	}
 
-	if err == nil {
-		bodyContent = transformEncoding(ctx, bodyContent)
+	if isFileURL(page.URL) {
+		bodyContent, err := getContentByFile(ctx, page)
+		return bodyContent, "", err
 	}
 
-	return
+	res, err := fetchPageWithSession(ctx, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res.Body, res.Header.Get("Content-Type"), nil
 }
 
 func getContentByCommand(ctx context.Context, page *models.Page) ([]byte, error) {
@@ -133,101 +173,91 @@ func getContentByCommand(ctx context.Context, page *models.Page) ([]byte, error)
 
 	ll.Debug().Msg("Resolve using command")
 
+	timeout := cmdContent.Timeout
+	if timeout <= 0 {
+		timeout = defaultCommandTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	var outb, errb bytes.Buffer
-	cmd := exec.Command(cmdContent.Name, cmdContent.Args...) // #nosec G204
+	cmd := exec.CommandContext(ctx, cmdContent.Name, cmdContent.Args...) // #nosec G204
 	cmd.Stdout = &outb
 	cmd.Stderr = &errb
+	cmd.Stdin = strings.NewReader(cmdContent.Stdin)
+	cmd.Dir = cmdContent.Dir
+	cmd.Env = makeCommandEnv(cmdContent.Env)
+	cmd.WaitDelay = time.Second
+
 	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		ll.Error().Dur("timeout", timeout).Msg("Command timed out")
+		return nil, models.NewScrapeError(
+			models.ErrKindTimeout,
+			fmt.Errorf("command %q timed out after %s", cmdContent.Name, timeout),
+		)
+	}
+
 	if err != nil {
 		ll.Error().Msg("Command error")
 		ll.Trace().
 			Err(err).
 			Str("stderr", errb.String()).
 			Msg("Command error trace")
-	}
 
-	return outb.Bytes(), err
-}
-
-func getContentByRequest(ctx context.Context, page *models.Page) ([]byte, error) {
-	span := trace.SpanFromContext(ctx)
-	span.SetAttributes(attribute.String("page.url", page.URL))
-
-	defer func() {
-		span.End()
-	}()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page.URL, nil)
-	ll := zerolog.Ctx(ctx).With().
-		Str("page_url", page.URL).
-		Str("page_namespace", page.Namespace()).
-		Logger()
-
-	if err != nil {
-		ll.Err(err).
-			Msg("Request initialization failed")
-		return nil, err
+		return outb.Bytes(), models.NewScrapeError(
+			models.ErrKindCommand,
+			fmt.Errorf("command %q failed: %w", cmdContent.Name, err),
+		)
 	}
 
-	randomUserAgent := userAgents[rand.Intn(len(userAgents))] // #nosec G404
-	req.Header.Add("User-Agent", randomUserAgent)
-
-	res, err := httpClient.Do(req)
-
-	if res == nil {
-		ll.Error().
-			Err(err).
-			Msg("Request failed - empty response")
-		return nil, err
-	}
-
-	if err != nil {
-		ll.Error().
-			Err(err).
-			Int("status", res.StatusCode).
-			Msg("Request failed")
-		ll.Trace().
-			Stack().
-			Err(err).
-			Str("content", fmt.Sprintf("%v", res)).
-			Msg("Error response content")
-		return nil, err
-	}
+	return outb.Bytes(), nil
+}
 
-	defer func() {
-		if err := res.Body.Close(); err != nil {
-			ll.Error().
-				Err(err).
-				Int("status", res.StatusCode).
-				Msg("Unable to close body")
+// makeCommandEnv creates the command environment, only the PATH and HOME
+// are inherited, so the server secrets are not leaked to the command
+func makeCommandEnv(env map[string]string) []string {
+	result := make([]string, 0, len(env)+2)
+	for _, name := range []string{"PATH", "HOME"} {
+		if value, ok := os.LookupEnv(name); ok {
+			result = append(result, name+"="+value)
 		}
-	}()
-
-	bodyContent, err := io.ReadAll(res.Body)
-	if err != nil {
-		ll.Error().
-			Err(err).
-			Int("status", res.StatusCode).
-			Msg("Failed to read a body")
+	}
 
-		return []byte{}, err
+	for name, value := range env {
+		result = append(result, name+"="+value)
 	}
 
-	return bodyContent, err
+	return result
 }
 
-func (r *pageContentResolver) applyFilters(ctx context.Context, content string) string {
+// applyFilters applies the resolver filters (ex. the HTML conversion) followed by the page filters
+func applyFilters(
+	ctx context.Context,
+	page *models.Page,
+	resolverFilters []func(*models.Page) filters.PageFilter,
+	content string,
+) string {
 	if strings.TrimSpace(content) == "" {
 		return ""
 	}
 
-	var err error
-
 	ll := zerolog.Ctx(ctx)
 
-	for _, newFilter := range r.filters {
-		filter := newFilter(&r.page)
+	pageFilters, err := filters.NewPageFilters(page)
+	if err != nil {
+		ll.Warn().
+			Err(err).
+			Msg("Unable to create the page filters")
+	}
+
+	allFilters := make([]filters.PageFilter, 0, len(resolverFilters)+len(pageFilters))
+	for _, newFilter := range resolverFilters {
+		allFilters = append(allFilters, newFilter(page))
+	}
+	allFilters = append(allFilters, pageFilters...)
 
+	for _, filter := range allFilters {
 		if !filter.IsEnabled() {
 			continue
 		}
@@ -255,43 +285,51 @@ func (r *pageContentResolver) applyFilters(ctx context.Context, content string)
 	return strings.TrimSpace(content)
 }
 
-func transformEncoding(ctx context.Context, content []byte) []byte {
-	bytesReader := bytes.NewReader(content)
-
+// transformEncoding converts the content to UTF-8, the encoding is taken from the page configuration,
+// then from the Content-Type charset and finally it is detected from the content
+func transformEncoding(ctx context.Context, page *models.Page, content []byte, contentType string) []byte {
 	ll := zerolog.Ctx(ctx)
 
-	e, name, _, err := DetermineEncodingFromReader(bytes.NewReader(content))
-	if err != nil {
-		ll.Warn().
-			Err(err).
-			Msg("Unable to determine the encoding")
-		return content
-	}
+	e, name := determineEncoding(page, content, contentType)
 
 	ll.Trace().
 		Str("encoding", name).
+		Str("content_type", contentType).
 		Msg("Found encoding")
 
-	reader := transform.NewReader(bytesReader, e.NewDecoder())
+	reader := transform.NewReader(bytes.NewReader(content), e.NewDecoder())
 	result, err := io.ReadAll(reader)
 	if err != nil {
 		ll.Warn().
 			Err(err).
+			Str("encoding", name).
 			Msg("Unable to read from reader")
+		return content
 	}
 
 	return result
 }
 
-// DetermineEncodingFromReader based on the content
-func DetermineEncodingFromReader(
-	r io.Reader,
-) (e encoding.Encoding, name string, certain bool, err error) {
-	b, err := bufio.NewReader(r).Peek(1024)
-	if err != nil {
-		return
+func determineEncoding(page *models.Page, content []byte, contentType string) (encoding.Encoding, string) {
+	if page.Encoding != "" {
+		if e, name := charset.Lookup(page.Encoding); e != nil {
+			return e, name
+		}
+	}
+
+	e, name, _ := charset.DetermineEncoding(content, contentType)
+	return e, name
+}
+
+// validateEncoding checks whether the encoding of the page is known
+func validateEncoding(page *models.Page) error {
+	if page.Encoding == "" {
+		return nil
+	}
+
+	if e, _ := charset.Lookup(page.Encoding); e == nil {
+		return fmt.Errorf("unknown encoding %q", page.Encoding)
 	}
 
-	e, name, certain = charset.DetermineEncoding(b, "")
-	return
+	return nil
 }
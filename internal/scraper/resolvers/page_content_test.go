@@ -0,0 +1,84 @@
+package resolvers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContentByCommandUsesStdin(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{
+		Command: models.CommandsConfig{
+			Content: models.CommandConfig{Name: "cat", Stdin: "menu of the day"},
+		},
+	}
+
+	content, err := getContentByCommand(t.Context(), &page)
+
+	assert.NoError(err)
+	assert.Equal("menu of the day", string(content))
+}
+
+func TestGetContentByCommandTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{
+		Command: models.CommandsConfig{
+			Content: models.CommandConfig{
+				Name:    "sleep",
+				Args:    []string{"5"},
+				Timeout: 100 * time.Millisecond,
+			},
+		},
+	}
+
+	_, err := getContentByCommand(t.Context(), &page)
+
+	assert.Equal(models.ErrKindTimeout, models.AsScrapeError(err).Kind)
+}
+
+func TestTransformEncoding(t *testing.T) {
+	assert := assert.New(t)
+
+	// "Čočková polévka" encoded in windows-1250
+	content := "<p>\xc8o\xe8kov\xe1 pol\xe9vka</p>"
+	meta := `<meta charset="windows-1250">`
+
+	tests := []struct {
+		name        string
+		encoding    string
+		contentType string
+		content     string
+		want        string
+	}{
+		{
+			name:        "content type charset",
+			contentType: "text/html; charset=windows-1250",
+			content:     content,
+			want:        "<p>Čočková polévka</p>",
+		},
+		{
+			name:        "page encoding",
+			encoding:    "cp1250",
+			contentType: "text/html; charset=utf-8",
+			content:     content,
+			want:        "<p>Čočková polévka</p>",
+		},
+		{
+			name:        "detected",
+			contentType: "text/html",
+			content:     meta + content,
+			want:        meta + "<p>Čočková polévka</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		page := models.Page{Encoding: tt.encoding}
+		result := transformEncoding(t.Context(), &page, []byte(tt.content), tt.contentType)
+		assert.Equal(tt.want, string(result), tt.name)
+	}
+}
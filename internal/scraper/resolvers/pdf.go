@@ -1,20 +1,105 @@
 package resolvers
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"math"
+	"strings"
 
+	"github.com/ledongthuc/pdf"
 	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/filters"
+	"github.com/rs/zerolog"
 )
 
+// pdfRowTolerance maximal vertical distance of the texts in the same row
+const pdfRowTolerance = 1.0
+
 type pdfResolver struct {
-	page models.Page
+	page    models.Page
+	filters []func(*models.Page) filters.PageFilter
 }
 
-func (u *pdfResolver) Resolve(_ context.Context) models.RunResult {
-	return models.RunResult{
+func (u *pdfResolver) Resolve(ctx context.Context) models.RunResult {
+	ll := zerolog.Ctx(ctx).With().
+		Str("page_url", u.page.URL).
+		Logger()
+
+	ll.Debug().Msg("Resolving pdf")
+
+	bodyContent, err := getRawContentForWebPage(ctx, &u.page)
+	if err != nil {
+		return u.withLink(makeErrorResult(u.page, err))
+	}
+
+	text, err := extractPdfText(bodyContent)
+	if err != nil {
+		ll.Warn().
+			Err(err).
+			Msg("Unable to extract the text from the pdf")
+		return u.withLink(makeParseErrorResult(u.page, err))
+	}
+
+	content := applyFilters(ctx, &u.page, u.filters, text)
+	if content == "" {
+		ll.Warn().Msg("Content resolved but the content is empty")
+		return u.withLink(makeEmptyResult(u.page, "pdf"))
+	}
+
+	return u.withLink(models.RunResult{
 		Page:    u.page,
-		Content: u.page.URL,
+		Content: content,
 		Status:  models.RunSuccess,
 		Kind:    "pdf",
+	})
+}
+
+// withLink appends the link to the original document to the result content,
+// the link is kept when the text could not be extracted, so the menu can still be opened
+func (u *pdfResolver) withLink(res models.RunResult) models.RunResult {
+	link := fmt.Sprintf("[PDF](%s)", u.page.URL)
+	if content := strings.TrimSpace(res.Content); content != "" {
+		link = content + "\n\n" + link
+	}
+	res.Content = link
+
+	return res
+}
+
+// extractPdfText extracts the text of all pages, one line for each text row,
+// the row ends when the vertical position of the text changes
+func extractPdfText(content []byte) (text string, err error) {
+	// the pdf library panics on the malformed content streams
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("malformed pdf content: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", err
 	}
+
+	var sb strings.Builder
+	for pageNum := 1; pageNum <= reader.NumPage(); pageNum++ {
+		page := reader.Page(pageNum)
+		if page.V.IsNull() {
+			continue
+		}
+
+		texts := page.Content().Text
+		for i, text := range texts {
+			if i > 0 && math.Abs(text.Y-texts[i-1].Y) > pdfRowTolerance {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(text.S)
+		}
+		if len(texts) > 0 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String(), nil
 }
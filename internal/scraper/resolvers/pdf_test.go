@@ -0,0 +1,73 @@
+package resolvers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractPdfText(t *testing.T) {
+	assert := assert.New(t)
+
+	content, err := os.ReadFile("testdata/menu.pdf")
+	assert.NoError(err)
+
+	text, err := extractPdfText(content)
+	assert.NoError(err)
+	assert.Equal("Monday\nGoulash 159\nTuesday\nSchnitzel 189\n", text)
+
+	_, err = extractPdfText([]byte("<html>not a pdf</html>"))
+	assert.Error(err)
+}
+
+func TestPdfResolverKeepsLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/menu.pdf", "/scanned.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			http.ServeFile(w, r, "testdata"+r.URL.Path)
+		case "/broken.pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("not a pdf"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		status  models.RunResultStatus
+		content string
+	}{
+		{
+			name:    "text",
+			path:    "/menu.pdf",
+			status:  models.RunSuccess,
+			content: "Monday\nGoulash 159\nTuesday\nSchnitzel 189",
+		},
+		{name: "scanned", path: "/scanned.pdf", status: models.RunEmpty},
+		{name: "unreadable", path: "/broken.pdf", status: models.RunError},
+		{name: "fetch error", path: "/missing.pdf", status: models.RunError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			page := models.Page{CodeName: "bistro", Category: "food", URL: server.URL + tt.path}
+			res := (&pdfResolver{page: page, filters: textFilters}).Resolve(t.Context())
+
+			assert.Equal(tt.status, res.Status)
+			assert.Contains(res.Content, "[PDF]("+page.URL+")")
+			if tt.content != "" {
+				assert.Equal(tt.content+"\n\n[PDF]("+page.URL+")", res.Content)
+			}
+		})
+	}
+}
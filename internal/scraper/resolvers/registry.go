@@ -0,0 +1,142 @@
+package resolvers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pestanko/miniscrape/internal/models"
+)
+
+// ResolverFactory creates a new instance of the resolver for the page
+type ResolverFactory func(page models.Page) PageResolver
+
+// ResolverDefinition describes the resolver to be registered
+type ResolverDefinition struct {
+	// Names under which the resolver is registered, the first one is the main name
+	Names []string
+	// Factory creates the resolver instance
+	Factory ResolverFactory
+	// Validate checks the page configuration required by the resolver,
+	// it is called when the categories are loaded, nil - no requirements
+	Validate func(page *models.Page) error
+}
+
+// Registry of the available resolvers
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[string]*ResolverDefinition
+}
+
+// NewRegistry creates a new empty instance of the registry
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: map[string]*ResolverDefinition{},
+	}
+}
+
+// Register the resolver under all its names
+func (r *Registry) Register(def ResolverDefinition) error {
+	if len(def.Names) == 0 {
+		return fmt.Errorf("resolver has to have at least one name")
+	}
+	if def.Factory == nil {
+		return fmt.Errorf("resolver %q has no factory", def.Names[0])
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range def.Names {
+		if _, ok := r.definitions[name]; ok {
+			return fmt.Errorf("resolver %q is already registered", name)
+		}
+	}
+
+	for _, name := range def.Names {
+		r.definitions[name] = &def
+	}
+
+	return nil
+}
+
+// Lookup the resolver definition by its name
+func (r *Registry) Lookup(name string) (*ResolverDefinition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, ok := r.definitions[name]
+	return def, ok
+}
+
+// Names returns all registered resolver names
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.definitions))
+	for name := range r.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ValidatePage checks whether the page resolver is registered
+// and the page has the configuration the resolver requires
+func (r *Registry) ValidatePage(page *models.Page) error {
+	if len(page.Sources) != 0 {
+		for idx, src := range page.Sources {
+			srcPage := page.WithSource(src)
+			if err := r.ValidatePage(&srcPage); err != nil {
+				return fmt.Errorf("source %d: %w", idx, err)
+			}
+		}
+		return nil
+	}
+
+	def, ok := r.Lookup(page.Resolver)
+	if !ok {
+		return fmt.Errorf("unknown resolver %q, available: %v", page.Resolver, r.Names())
+	}
+
+	if def.Validate == nil {
+		return nil
+	}
+
+	if err := def.Validate(page); err != nil {
+		return fmt.Errorf("invalid configuration for resolver %q: %w", page.Resolver, err)
+	}
+
+	return nil
+}
+
+var defaultRegistry = NewRegistry()
+
+// Register the resolver to the default registry
+func Register(def ResolverDefinition) error {
+	return defaultRegistry.Register(def)
+}
+
+// MustRegister the resolver to the default registry, panics on failure
+func MustRegister(def ResolverDefinition) {
+	if err := Register(def); err != nil {
+		panic(err)
+	}
+}
+
+// ValidatePage validates the page encoding, login, exclude selectors and the page using the default registry
+func ValidatePage(page *models.Page) error {
+	if err := validateEncoding(page); err != nil {
+		return err
+	}
+	if err := validateExclude(page); err != nil {
+		return err
+	}
+	if err := validateAuth(page); err != nil {
+		return err
+	}
+
+	return defaultRegistry.ValidatePage(page)
+}
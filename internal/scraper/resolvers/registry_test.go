@@ -0,0 +1,58 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticResolver struct {
+	page models.Page
+}
+
+func (r *staticResolver) Resolve(_ context.Context) models.RunResult {
+	return models.RunResult{Page: r.page, Content: "static", Status: models.RunSuccess}
+}
+
+func TestRegistryRegisterAndValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewRegistry()
+	err := registry.Register(ResolverDefinition{
+		Names: []string{"static", "const"},
+		Factory: func(page models.Page) PageResolver {
+			return &staticResolver{page: page}
+		},
+	})
+	assert.NoError(err)
+
+	def, ok := registry.Lookup("const")
+	assert.True(ok)
+	assert.Equal("static", def.Factory(models.Page{}).Resolve(t.Context()).Content)
+
+	assert.NoError(registry.ValidatePage(&models.Page{Resolver: "static"}))
+	assert.Error(registry.ValidatePage(&models.Page{Resolver: "statc"}))
+}
+
+func TestRegistryRejectsDuplicateNames(t *testing.T) {
+	registry := NewRegistry()
+	def := ResolverDefinition{
+		Names: []string{"static"},
+		Factory: func(page models.Page) PageResolver {
+			return &staticResolver{page: page}
+		},
+	}
+
+	assert.NoError(t, registry.Register(def))
+	assert.Error(t, registry.Register(def))
+}
+
+func TestValidatePageRequiresSelector(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Error(ValidatePage(&models.Page{Resolver: "default"}))
+	assert.NoError(ValidatePage(&models.Page{Resolver: "default", Query: "#menu"}))
+	assert.NoError(ValidatePage(&models.Page{Resolver: "url_only"}))
+}
@@ -0,0 +1,80 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pestanko/miniscrape/internal/models"
+)
+
+// newPageRequest creates a new HTTP request based on the page request configuration
+func newPageRequest(ctx context.Context, page *models.Page) (*http.Request, error) {
+	cfg := &page.Request
+
+	method := strings.ToUpper(cfg.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	reqURL, err := url.Parse(page.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Query) != 0 {
+		query := reqURL.Query()
+		for key, value := range cfg.Query {
+			query.Set(key, value)
+		}
+		reqURL.RawQuery = query.Encode()
+	}
+
+	body, contentType, err := makeRequestBody(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	randomUserAgent := userAgents[rand.Intn(len(userAgents))] // #nosec G404
+	req.Header.Set("User-Agent", randomUserAgent)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for key, value := range cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	for name, value := range cfg.Cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+
+	return req, nil
+}
+
+func makeRequestBody(cfg *models.RequestConfig) (io.Reader, string, error) {
+	switch {
+	case len(cfg.Form) != 0 && cfg.JSON != "":
+		return nil, "", fmt.Errorf("request can not have both form and json body")
+	case len(cfg.Form) != 0:
+		form := url.Values{}
+		for key, value := range cfg.Form {
+			form.Set(key, value)
+		}
+		return strings.NewReader(form.Encode()), "application/x-www-form-urlencoded", nil
+	case cfg.JSON != "":
+		return strings.NewReader(cfg.JSON), "application/json", nil
+	default:
+		return nil, "", nil
+	}
+}
@@ -0,0 +1,110 @@
+package resolvers
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPageRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		name        string
+		request     models.RequestConfig
+		method      string
+		url         string
+		body        string
+		contentType string
+	}{
+		{
+			name:   "default get",
+			method: http.MethodGet,
+			url:    "https://bistro.example/menu?lang=cs",
+		},
+		{
+			name:    "query merge",
+			request: models.RequestConfig{Method: "get", Query: map[string]string{"lang": "en", "week": "42"}},
+			method:  http.MethodGet,
+			url:     "https://bistro.example/menu?lang=en&week=42",
+		},
+		{
+			name:        "form body",
+			request:     models.RequestConfig{Method: "post", Form: map[string]string{"day": "monday", "q": "a b"}},
+			method:      http.MethodPost,
+			url:         "https://bistro.example/menu?lang=cs",
+			body:        "day=monday&q=a+b",
+			contentType: "application/x-www-form-urlencoded",
+		},
+		{
+			name:        "json body",
+			request:     models.RequestConfig{Method: "POST", JSON: `{"day":"monday"}`},
+			method:      http.MethodPost,
+			url:         "https://bistro.example/menu?lang=cs",
+			body:        `{"day":"monday"}`,
+			contentType: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		page := models.Page{URL: "https://bistro.example/menu?lang=cs", Request: tt.request}
+
+		req, err := newPageRequest(t.Context(), &page)
+		if !assert.NoError(err, tt.name) {
+			continue
+		}
+
+		assert.Equal(tt.method, req.Method, tt.name)
+		assert.Equal(tt.url, req.URL.String(), tt.name)
+		assert.Equal(tt.contentType, req.Header.Get("Content-Type"), tt.name)
+		assert.NotEmpty(req.Header.Get("User-Agent"), tt.name)
+
+		var body []byte
+		if req.Body != nil {
+			body, _ = io.ReadAll(req.Body)
+		}
+		assert.Equal(tt.body, string(body), tt.name)
+	}
+}
+
+func TestNewPageRequestHeadersAndCookies(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{
+		URL: "https://bistro.example/menu",
+		Request: models.RequestConfig{
+			Headers: map[string]string{"Accept-Language": "cs", "User-Agent": "miniscrape"},
+			Cookies: map[string]string{"session": "abc"},
+		},
+	}
+
+	req, err := newPageRequest(t.Context(), &page)
+
+	assert.NoError(err)
+	assert.Equal("cs", req.Header.Get("Accept-Language"))
+	assert.Equal("miniscrape", req.Header.Get("User-Agent"))
+	cookie, err := req.Cookie("session")
+	if assert.NoError(err) {
+		assert.Equal("abc", cookie.Value)
+	}
+}
+
+func TestNewPageRequestFormAndJSONConflict(t *testing.T) {
+	assert := assert.New(t)
+
+	page := models.Page{
+		URL: "https://bistro.example/menu",
+		Request: models.RequestConfig{
+			Method: http.MethodPost,
+			Form:   map[string]string{"day": "monday"},
+			JSON:   `{"day":"monday"}`,
+		},
+	}
+
+	_, err := newPageRequest(t.Context(), &page)
+
+	assert.Error(err)
+}
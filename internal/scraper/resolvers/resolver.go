@@ -2,6 +2,8 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
 	"github.com/pestanko/miniscrape/internal/models"
 	"github.com/pestanko/miniscrape/internal/scraper/filters"
@@ -12,39 +14,191 @@ type PageResolver interface {
 	Resolve(ctx context.Context) models.RunResult
 }
 
+// htmlFilters filters for the resolvers producing HTML content,
+// the HTML is converted (to markdown by default) before the page filters are applied
+var htmlFilters = []func(*models.Page) filters.PageFilter{
+	filters.NewHTMLModeConverter,
+}
+
+// textFilters filters for the resolvers producing text content,
+// only the page filters are applied
+var textFilters []func(*models.Page) filters.PageFilter
+
 // NewPageResolver creates a new instance of the page resovler
 func NewPageResolver(page models.Page) PageResolver {
-	switch page.Resolver {
-	case "url_only", "urlonly", "url-only":
-		return &urlOnlyResolver{
+	if len(page.Sources) != 0 {
+		return &fallbackResolver{
 			page: page,
 		}
-	case "url", "iframe":
-		return &iframeResolver{
+	}
+
+	def, ok := defaultRegistry.Lookup(page.Resolver)
+	if !ok {
+		return &errorResolver{
 			page: page,
+			err:  fmt.Errorf("unknown resolver %q", page.Resolver),
 		}
-	case "image", "img":
-		return &imageResolver{
-			page:   page,
-			client: httpClient,
-		}
-	case "pdf":
-		return &pdfResolver{
-			page: page,
+	}
+
+	return def.Factory(page)
+}
+
+func init() {
+	MustRegister(ResolverDefinition{
+		Names: []string{"default", "get"},
+		Factory: func(page models.Page) PageResolver {
+			return &pageContentResolver{
+				page:    page,
+				client:  httpClient,
+				filters: htmlFilters,
+			}
+		},
+		Validate: requireSelector,
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"url_only", "urlonly", "url-only"},
+		Factory: func(page models.Page) PageResolver {
+			return &urlOnlyResolver{
+				page: page,
+			}
+		},
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"iframe", "url"},
+		Factory: func(page models.Page) PageResolver {
+			return &iframeResolver{
+				page: page,
+			}
+		},
+		Validate: func(page *models.Page) error {
+			if page.Iframe.URLOnly {
+				return nil
+			}
+			return requireSelector(page)
+		},
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"img", "image"},
+		Factory: func(page models.Page) PageResolver {
+			return &imageResolver{
+				page:   page,
+				client: httpClient,
+			}
+		},
+		Validate: requireSelector,
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"pdf"},
+		Factory: func(page models.Page) PageResolver {
+			return &pdfResolver{
+				page:    page,
+				filters: textFilters,
+			}
+		},
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"json"},
+		Factory: func(page models.Page) PageResolver {
+			return &jsonResolver{
+				page:    page,
+				filters: textFilters,
+			}
+		},
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"feed", "rss", "atom"},
+		Factory: func(page models.Page) PageResolver {
+			return &feedResolver{
+				page:    page,
+				filters: htmlFilters,
+			}
+		},
+		Validate: func(page *models.Page) error {
+			return validatePattern(page.Feed.Title)
+		},
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"jsonld", "json-ld", "schema"},
+		Factory: func(page models.Page) PageResolver {
+			return &jsonLDResolver{
+				page:    page,
+				filters: htmlFilters,
+			}
+		},
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"fixture", "file"},
+		Factory: func(page models.Page) PageResolver {
+			return &fixtureResolver{
+				page: page,
+			}
+		},
+		Validate: func(page *models.Page) error {
+			if page.Fixture == "" {
+				return fmt.Errorf("fixture is required")
+			}
+			return requireSelector(page)
+		},
+	})
+	MustRegister(ResolverDefinition{
+		Names: []string{"follow"},
+		Factory: func(page models.Page) PageResolver {
+			return &followResolver{
+				page: page,
+			}
+		},
+		Validate: validateFollow,
+	})
+}
+
+func requireSelector(page *models.Page) error {
+	if page.Query == "" && page.XPath == "" {
+		return fmt.Errorf("query or xpath is required")
+	}
+	return nil
+}
+
+func validatePattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	_, err := regexp.Compile(pattern)
+	return err
+}
+
+func validateFollow(page *models.Page) error {
+	if len(page.Follow.Hops) == 0 {
+		return fmt.Errorf("at least one hop is required")
+	}
+
+	for idx, hop := range page.Follow.Hops {
+		if hop.Query == "" && hop.XPath == "" {
+			return fmt.Errorf("hop %d: query or xpath is required", idx)
 		}
-	case "get", "default":
-		fallthrough
-	default:
-		return &pageContentResolver{
-			page:   page,
-			client: httpClient,
-			filters: []func(*models.Page) filters.PageFilter{
-				filters.NewHTMLToMdConverter,
-				filters.NewNewLineTrimConverter,
-				filters.NewCutFilter,
-				filters.NewDayFilter,
-				filters.NewCutLineFilter,
-			},
+		if err := validatePattern(hop.Pattern); err != nil {
+			return fmt.Errorf("hop %d: %w", idx, err)
 		}
 	}
+
+	target := *page
+	target.Resolver = page.Follow.Resolver
+	target.Follow = models.FollowConfig{}
+	if target.Resolver == "" {
+		target.Resolver = "default"
+	}
+	if target.Resolver == "follow" {
+		return fmt.Errorf("follow resolver can not be used as the target resolver")
+	}
+
+	return ValidatePage(&target)
+}
+
+// errorResolver resolver that always fails, used for misconfigured pages
+type errorResolver struct {
+	page models.Page
+	err  error
+}
+
+func (r *errorResolver) Resolve(_ context.Context) models.RunResult {
+	return makeErrorResult(r.page, r.err)
 }
@@ -0,0 +1,156 @@
+package resolvers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pestanko/miniscrape/internal/cache"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/rs/zerolog"
+)
+
+type responseCacheKey struct{}
+
+// responseCache keeps the last response of the page resolved in the context,
+// it is used to make conditional requests using the stored validators
+type responseCache struct {
+	cache     cache.Cache
+	namespace cache.ItemNamespace
+	// entry response used in the current run
+	entry *cache.ResponseEntry
+	// notModified whether the server responded with 304 in the current run
+	notModified bool
+}
+
+func withResponseCache(ctx context.Context, c cache.Cache, nm cache.ItemNamespace) context.Context {
+	return context.WithValue(ctx, responseCacheKey{}, &responseCache{
+		cache:     c,
+		namespace: nm,
+	})
+}
+
+// withoutResponseCache disables the response cache for the requests
+// which are not the page content (ex. the login request)
+func withoutResponseCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, responseCacheKey{}, (*responseCache)(nil))
+}
+
+func responseCacheFromContext(ctx context.Context) *responseCache {
+	rc, _ := ctx.Value(responseCacheKey{}).(*responseCache)
+	return rc
+}
+
+// addValidators adds conditional headers to the request if there is a stored response for the URL
+func (rc *responseCache) addValidators(req *http.Request) {
+	if rc == nil {
+		return
+	}
+
+	entry, ok := rc.cache.GetResponse(rc.namespace, req.URL.String())
+	if !ok {
+		return
+	}
+
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// handleResponse reuses the stored body for the 304 response
+// and stores the validators of the new response,
+// the state of the previous response is always reset, so it never leaks to the next request
+func (rc *responseCache) handleResponse(ctx context.Context, reqURL string, res *pageResponse) {
+	if rc == nil || res == nil {
+		return
+	}
+
+	rc.entry = nil
+	rc.notModified = false
+
+	ll := zerolog.Ctx(ctx)
+
+	if res.StatusCode == http.StatusNotModified {
+		entry, ok := rc.cache.GetResponse(rc.namespace, reqURL)
+		if !ok {
+			return
+		}
+		ll.Debug().Str("url", reqURL).Msg("Content not modified - reusing the stored response")
+		res.StatusCode = http.StatusOK
+		res.Body = entry.Body
+		if res.Header.Get("Content-Type") == "" && entry.ContentType != "" {
+			res.Header.Set("Content-Type", entry.ContentType)
+		}
+		rc.entry = &entry
+		rc.notModified = true
+		return
+	}
+
+	etag, lastModified := res.Header.Get("ETag"), res.Header.Get("Last-Modified")
+	if res.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return
+	}
+
+	rc.entry = &cache.ResponseEntry{
+		URL:          reqURL,
+		ETag:         etag,
+		LastModified: lastModified,
+		ContentType:  res.Header.Get("Content-Type"),
+		Body:         res.Body,
+	}
+	if err := rc.cache.StoreResponse(rc.namespace, *rc.entry); err != nil {
+		ll.Warn().Err(err).Msg("Unable to store the response")
+	}
+}
+
+// storeContent stores the content processed from the last response
+// together with the fingerprint of the page configuration it has been processed with
+func storeContent(ctx context.Context, page *models.Page, content string) {
+	rc := responseCacheFromContext(ctx)
+	if rc == nil || rc.entry == nil {
+		return
+	}
+
+	key := contentKey(page)
+	if rc.entry.Content == content && rc.entry.ContentKey == key {
+		return
+	}
+
+	rc.entry.Content = content
+	rc.entry.ContentKey = key
+	if err := rc.cache.StoreResponse(rc.namespace, *rc.entry); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to store the response content")
+	}
+}
+
+// reusableContent returns the content processed from the same unmodified response
+// with the same page configuration, the content can not be reused if it depends on the current day
+func reusableContent(ctx context.Context, page *models.Page) (string, bool) {
+	rc := responseCacheFromContext(ctx)
+	if rc == nil || !rc.notModified || rc.entry.Content == "" || page.Filters.UsesDay() {
+		return "", false
+	}
+
+	if rc.entry.ContentKey != contentKey(page) {
+		return "", false
+	}
+
+	return rc.entry.Content, true
+}
+
+// contentKey returns the fingerprint of the page configuration,
+// the processed content is reused only for the same configuration
+func contentKey(page *models.Page) string {
+	content, err := json.Marshal(page)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,80 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pestanko/miniscrape/internal/cache"
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCacheFollowTargetWithoutValidators(t *testing.T) {
+	assert := assert.New(t)
+
+	soup := "Soup A"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"index"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"index"`)
+		_, _ = fmt.Fprint(w, `<html><body><a class="menu" href="/menu">Menu</a></body></html>`)
+	})
+	mux.HandleFunc("/menu", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `<html><body><div id="menu">%s</div></body></html>`, soup)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "bistro",
+		Category: "food",
+		URL:      server.URL + "/",
+		Query:    "#menu",
+		Resolver: "follow",
+		Follow:   models.FollowConfig{Hops: []models.FollowHop{{Query: "a.menu"}}},
+	}
+
+	root := t.TempDir()
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	resolve := func(day time.Time) models.RunResult {
+		c := cache.NewCache(config.CacheCfg{Enabled: true, Root: root}, day)
+		return NewGetCachedPageResolver(page, c).Resolve(t.Context())
+	}
+
+	assert.Equal("Soup A", resolve(day).Content)
+
+	soup = "Soup B"
+	assert.Equal("Soup B", resolve(day.AddDate(0, 0, 1)).Content)
+}
+
+func TestResponseCacheReusesContentOnlyForSameConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"menu"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"menu"`)
+		_, _ = fmt.Fprint(w, `<html><body><p id="soup">Lentil soup</p><p id="main">Goulash</p></body></html>`)
+	}))
+	defer server.Close()
+
+	page := models.Page{CodeName: "bistro", Category: "food", URL: server.URL, Query: "#soup", Resolver: "default"}
+
+	root := t.TempDir()
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	c := cache.NewCache(config.CacheCfg{Enabled: true, Root: root}, day)
+	assert.Equal("Lentil soup", NewGetCachedPageResolver(page, c).Resolve(t.Context()).Content)
+
+	page.Query = "#main"
+	c = cache.NewCache(config.CacheCfg{Enabled: true, Root: root}, day.AddDate(0, 0, 1))
+	assert.Equal("Goulash", NewGetCachedPageResolver(page, c).Resolve(t.Context()).Content)
+}
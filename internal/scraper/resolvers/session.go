@@ -0,0 +1,254 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/rs/zerolog"
+	"golang.org/x/net/publicsuffix"
+)
+
+// session of the login host and account, the cookies are shared by all pages
+// logging in to the host with the same credentials
+type session struct {
+	mu       sync.Mutex
+	jar      http.CookieJar
+	loggedIn bool
+}
+
+// sessionStore keeps the sessions in memory for the lifetime of the process,
+// the cookie jars are not written to the disk, so the credentials are not stored anywhere
+type sessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+var defaultSessions = &sessionStore{
+	sessions: map[string]*session{},
+}
+
+// forPage returns the session of the page login host and credentials,
+// pages logging in to the same host with different accounts have separate sessions
+func (s *sessionStore) forPage(page *models.Page) *session {
+	key := sessionKey(&page.Auth)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[key]
+	if !ok {
+		jar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+		sess = &session{jar: jar}
+		s.sessions[key] = sess
+	}
+
+	return sess
+}
+
+// sessionKey identifies the session by the login host and the environment variables with the credentials
+func sessionKey(auth *models.AuthConfig) string {
+	host := auth.URL
+	if parsed, err := url.Parse(auth.URL); err == nil {
+		host = parsed.Host
+	}
+
+	return strings.Join([]string{host, auth.UsernameEnv, auth.PasswordEnv}, "\x00")
+}
+
+// pageClient returns the HTTP client for the page,
+// pages requiring the login use the cookie jar of their session
+func pageClient(page *models.Page) *http.Client {
+	if !page.Auth.IsEnabled() {
+		return &httpClient
+	}
+
+	client := httpClient
+	client.Jar = defaultSessions.forPage(page).jar
+
+	return &client
+}
+
+// fetchPageWithSession fetches the page, if the page requires the login,
+// it logs in first and logs in again when the session expires
+func fetchPageWithSession(ctx context.Context, page *models.Page) (*pageResponse, error) {
+	if !page.Auth.IsEnabled() {
+		return fetchPage(ctx, page)
+	}
+
+	sess := defaultSessions.forPage(page)
+	if err := sess.login(ctx, page, false); err != nil {
+		return nil, err
+	}
+
+	res, err := fetchPage(ctx, page)
+	if !isSessionExpired(page, res, err) {
+		return res, err
+	}
+
+	zerolog.Ctx(ctx).Info().
+		Str("page_namespace", page.Namespace()).
+		Str("login_url", page.Auth.URL).
+		Msg("Session expired - logging in again")
+
+	if err := sess.login(ctx, page, true); err != nil {
+		return nil, err
+	}
+
+	return fetchPage(ctx, page)
+}
+
+// login submits the login form, unless the session is already logged in
+func (s *session) login(ctx context.Context, page *models.Page, force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loggedIn && !force {
+		return nil
+	}
+	s.loggedIn = false
+
+	loginPage, err := makeLoginPage(page)
+	if err != nil {
+		return models.NewScrapeError(models.ErrKindAuth, err)
+	}
+
+	zerolog.Ctx(ctx).Debug().
+		Str("page_namespace", page.Namespace()).
+		Str("login_url", loginPage.URL).
+		Msg("Logging in")
+
+	loginCtx := withCassetteSensitiveParams(withoutResponseCache(ctx), page.Auth.UsernameField, page.Auth.PasswordField)
+	res, err := fetchPage(loginCtx, &loginPage)
+	if err != nil {
+		return models.NewScrapeError(models.ErrKindAuth, fmt.Errorf("login failed: %w", err))
+	}
+
+	if matchesExpired(page, res.Body) {
+		return models.NewScrapeError(models.ErrKindAuth, fmt.Errorf("login failed: login form is still shown"))
+	}
+
+	s.loggedIn = true
+	return nil
+}
+
+// makeLoginPage creates the page of the login request with the credentials
+func makeLoginPage(page *models.Page) (models.Page, error) {
+	auth := &page.Auth
+
+	fields := make(map[string]string, len(auth.Fields)+2)
+	for key, value := range auth.Fields {
+		fields[key] = value
+	}
+
+	for _, cred := range []struct{ field, env string }{
+		{auth.UsernameField, auth.UsernameEnv},
+		{auth.PasswordField, auth.PasswordEnv},
+	} {
+		if cred.field == "" {
+			continue
+		}
+		value, ok := os.LookupEnv(cred.env)
+		if !ok {
+			return models.Page{}, fmt.Errorf("environment variable %q with the credentials is not set", cred.env)
+		}
+		fields[cred.field] = value
+	}
+
+	loginPage := models.Page{
+		CodeName: page.CodeName,
+		Category: page.Category,
+		URL:      auth.URL,
+		Auth:     page.Auth,
+		Fetch:    page.Fetch,
+		Request: models.RequestConfig{
+			Method:  auth.Method,
+			Headers: page.Request.Headers,
+			Timeout: page.Request.Timeout,
+		},
+	}
+
+	if strings.EqualFold(auth.Method, http.MethodGet) {
+		loginPage.Request.Query = fields
+	} else {
+		loginPage.Request.Method = http.MethodPost
+		loginPage.Request.Form = fields
+	}
+
+	return loginPage, nil
+}
+
+// isSessionExpired whether the response shows the session has expired,
+// the server responded with 401/403, redirected to the login or shown the login form
+func isSessionExpired(page *models.Page, res *pageResponse, err error) bool {
+	if err != nil {
+		var scrapeErr *models.ScrapeError
+		return errors.As(err, &scrapeErr) &&
+			(scrapeErr.StatusCode == http.StatusUnauthorized || scrapeErr.StatusCode == http.StatusForbidden)
+	}
+
+	if res == nil {
+		return false
+	}
+
+	return isLoginURL(page, res.URL) || matchesExpired(page, res.Body)
+}
+
+// matchesExpired whether the content matches the expired session pattern
+func matchesExpired(page *models.Page, content []byte) bool {
+	if page.Auth.Expired == "" {
+		return false
+	}
+
+	expired, err := regexp.Compile(page.Auth.Expired)
+	return err == nil && expired.Match(content)
+}
+
+// isLoginURL whether the URL is the login URL of the page, the query is ignored
+func isLoginURL(page *models.Page, resURL string) bool {
+	loginURL, err := url.Parse(page.Auth.URL)
+	if err != nil {
+		return false
+	}
+
+	parsed, err := url.Parse(resURL)
+	if err != nil {
+		return false
+	}
+
+	return parsed.Host == loginURL.Host && parsed.Path == loginURL.Path
+}
+
+// validateAuth checks the login configuration of the page
+func validateAuth(page *models.Page) error {
+	auth := &page.Auth
+	if !auth.IsEnabled() {
+		return nil
+	}
+
+	loginURL, err := url.Parse(auth.URL)
+	if err != nil || loginURL.Host == "" {
+		return fmt.Errorf("auth: invalid login url %q", auth.URL)
+	}
+
+	if (auth.UsernameField == "") != (auth.UsernameEnv == "") {
+		return fmt.Errorf("auth: both usernameField and usernameEnv are required")
+	}
+	if (auth.PasswordField == "") != (auth.PasswordEnv == "") {
+		return fmt.Errorf("auth: both passwordField and passwordEnv are required")
+	}
+
+	if err := validatePattern(auth.Expired); err != nil {
+		return fmt.Errorf("auth: invalid expired pattern: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchWithSessionLogsInAndReusesSession(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("CANTEEN_USER", "jane")
+	t.Setenv("CANTEEN_PASSWORD", "secret")
+
+	var logins atomic.Int32
+	var sessionID atomic.Value
+	sessionID.Store("")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			_, _ = fmt.Fprint(w, `<form id="login"></form>`)
+			return
+		}
+		if r.FormValue("user") != "jane" || r.FormValue("pass") != "secret" || r.FormValue("remember") != "1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sid := fmt.Sprintf("session-%d", logins.Add(1))
+		sessionID.Store(sid)
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: sid, Path: "/"})
+		_, _ = fmt.Fprint(w, `Welcome`)
+	})
+	mux.HandleFunc("/menu", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("sid")
+		if err != nil || cookie.Value != sessionID.Load() {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<div id="menu">Schnitzel</div>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "canteen",
+		URL:      server.URL + "/menu",
+		Resolver: "default",
+		Query:    "#menu",
+		Auth: models.AuthConfig{
+			URL:           server.URL + "/login",
+			UsernameField: "user",
+			UsernameEnv:   "CANTEEN_USER",
+			PasswordField: "pass",
+			PasswordEnv:   "CANTEEN_PASSWORD",
+			Fields:        map[string]string{"remember": "1"},
+		},
+	}
+
+	assert.NoError(ValidatePage(&page))
+
+	res := NewPageResolver(page).Resolve(t.Context())
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Equal("Schnitzel", res.Content)
+
+	res = NewPageResolver(page).Resolve(t.Context())
+	assert.Equal("Schnitzel", res.Content)
+	assert.Equal(int32(1), logins.Load())
+
+	// the server forgets the session
+	sessionID.Store("expired")
+
+	res = NewPageResolver(page).Resolve(t.Context())
+	assert.Equal("Schnitzel", res.Content)
+	assert.Equal(int32(2), logins.Load())
+}
+
+func TestFetchWithSessionFailedLogin(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("CANTEEN_PASSWORD", "wrong")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	page := models.Page{
+		CodeName: "canteen",
+		URL:      server.URL + "/menu",
+		Resolver: "default",
+		Query:    "#menu",
+		Auth: models.AuthConfig{
+			URL:           server.URL + "/login",
+			PasswordField: "pass",
+			PasswordEnv:   "CANTEEN_PASSWORD",
+		},
+	}
+
+	res := NewPageResolver(page).Resolve(t.Context())
+	assert.Equal(models.RunError, res.Status)
+	assert.Equal(models.ErrKindAuth, res.Error.Kind)
+}
+
+func TestFetchWithSessionSeparateAccounts(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("PORTAL_ALICE", "alice")
+	t.Setenv("PORTAL_BOB", "bob")
+	t.Setenv("PORTAL_PASSWORD", "secret")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "user", Value: r.FormValue("user"), Path: "/"})
+		_, _ = fmt.Fprint(w, `Welcome`)
+	})
+	mux.HandleFunc("/menu", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("user")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `<div id="menu">Menu of %s</div>`, cookie.Value)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	makePage := func(userEnv string) models.Page {
+		return models.Page{
+			CodeName: "portal",
+			URL:      server.URL + "/menu",
+			Resolver: "default",
+			Query:    "#menu",
+			Auth: models.AuthConfig{
+				URL:           server.URL + "/login",
+				UsernameField: "user",
+				UsernameEnv:   userEnv,
+				PasswordField: "pass",
+				PasswordEnv:   "PORTAL_PASSWORD",
+			},
+		}
+	}
+
+	assert.Equal("Menu of alice", NewPageResolver(makePage("PORTAL_ALICE")).Resolve(t.Context()).Content)
+	assert.Equal("Menu of bob", NewPageResolver(makePage("PORTAL_BOB")).Resolve(t.Context()).Content)
+	assert.Equal("Menu of alice", NewPageResolver(makePage("PORTAL_ALICE")).Resolve(t.Context()).Content)
+}
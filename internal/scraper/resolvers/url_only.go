@@ -20,26 +20,72 @@ func (u *urlOnlyResolver) Resolve(_ context.Context) models.RunResult {
 	}
 }
 
+// iframeResolver resolves the document embedded in the page iframe,
+// the iframe source is resolved against the page URL
 type iframeResolver struct {
 	page models.Page
 }
 
-func (u *iframeResolver) Resolve(_ context.Context) models.RunResult {
-	return models.RunResult{
-		Page:    u.page,
-		Content: u.page.URL,
-		Status:  models.RunSuccess,
-		Kind:    "iframe",
+func (u *iframeResolver) Resolve(ctx context.Context) models.RunResult {
+	if u.page.Iframe.URLOnly {
+		return models.RunResult{
+			Page:    u.page,
+			Content: u.page.URL,
+			Status:  models.RunSuccess,
+			Kind:    "iframe",
+		}
+	}
+
+	hop := models.FollowHop{
+		Query: u.page.Iframe.Query,
+		XPath: u.page.Iframe.XPath,
+	}
+	if hop.Query == "" && hop.XPath == "" {
+		hop.Query = "iframe"
+	}
+
+	embedded := u.page
+	embedded.Resolver = "follow"
+	embedded.Follow = models.FollowConfig{
+		Hops: []models.FollowHop{hop},
 	}
+
+	res := (&followResolver{page: embedded}).Resolve(ctx)
+	res.Page = u.page
+
+	return res
 }
 
 func makeErrorResult(page models.Page, err error) models.RunResult {
+	scrapeErr := models.AsScrapeError(err)
 	return models.RunResult{
 		Page:    page,
-		Content: fmt.Sprintf("Error: %v\n", err),
+		Content: fmt.Sprintf("Error: %v\n", scrapeErr),
 		Status:  models.RunError,
 		Kind:    "error",
+		Error:   scrapeErr,
+	}
+}
+
+func makeSelectorMissResult(page models.Page, kind string, selector string) models.RunResult {
+	res := makeEmptyResult(page, kind)
+	res.Error = models.NewScrapeError(
+		models.ErrKindSelectorMiss,
+		fmt.Errorf("no content matched the selector %q", selector),
+	)
+	return res
+}
+
+func makeParseErrorResult(page models.Page, err error) models.RunResult {
+	return makeErrorResult(page, models.NewScrapeError(models.ErrKindParse, err))
+}
+
+// pageSelector returns the selector used to extract the content of the page
+func pageSelector(page *models.Page) string {
+	if page.Query != "" {
+		return page.Query
 	}
+	return page.XPath
 }
 
 func makeEmptyResult(page models.Page, kind string) models.RunResult {
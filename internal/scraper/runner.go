@@ -8,6 +8,7 @@ import (
 	"github.com/pestanko/miniscrape/internal/cache"
 	"github.com/pestanko/miniscrape/internal/config"
 	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/menu"
 	"github.com/pestanko/miniscrape/internal/scraper/resolvers"
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel/attribute"
@@ -57,6 +58,9 @@ func (a *asyncRunner) Run(ctx context.Context, selector models.RunSelector) []mo
 
 	zerolog.Ctx(ctx).Debug().Msg("Runner Started!")
 	pages := a.filterPages(selector)
+	for idx := range pages {
+		pages[idx].TargetDay = selector.Day
+	}
 	numberOfPages := len(pages)
 	ll := zerolog.Ctx(ctx).
 		With().
@@ -122,7 +126,7 @@ func (a *asyncRunner) startAsyncRequests(
 			ctx := ll.WithContext(ctx)
 			resolver := resolvers.NewGetCachedPageResolver(page, a.cache)
 
-			resChan <- resolver.Resolve(ctx)
+			resChan <- parseMenu(ctx, resolver.Resolve(ctx))
 		}()
 	}
 }
@@ -154,3 +158,21 @@ func (a *asyncRunner) filterPages(sel models.RunSelector) []models.Page {
 
 	return result
 }
+
+// parseMenu parses the structured menu from the content,
+// if the resolver has not provided it already
+func parseMenu(ctx context.Context, res models.RunResult) models.RunResult {
+	if !res.Page.Menu.Enabled || res.Status != models.RunSuccess || res.Menu != nil {
+		return res
+	}
+
+	parser, err := menu.NewParser(res.Page.Menu)
+	if err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("Unable to create the menu parser")
+		return res
+	}
+
+	res.Menu = parser.Parse(res.Content)
+
+	return res
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/pestanko/miniscrape/internal/cache"
 	"github.com/pestanko/miniscrape/internal/config"
 	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/filters"
+	"github.com/pestanko/miniscrape/internal/scraper/menu"
+	"github.com/pestanko/miniscrape/internal/scraper/resolvers"
 
 	"github.com/pestanko/miniscrape/pkg/utils"
 )
@@ -20,7 +23,7 @@ type Service struct {
 // NewService create a new instance of the service
 func NewService(cfg *config.AppConfig) *Service {
 	categoriesLoader := func(ctx context.Context) *[]models.Category {
-		categories := models.LoadCategories(ctx, cfg)
+		categories := models.LoadCategories(ctx, cfg, resolvers.ValidatePage, menu.ValidatePage, filters.ValidatePage)
 		return &categories
 	}
 
@@ -32,7 +35,13 @@ func NewService(cfg *config.AppConfig) *Service {
 
 // Scrape the pages based on selector
 func (s *Service) Scrape(ctx context.Context, selector models.RunSelector) []models.RunResult {
-	runner := NewAsyncRunner(&s.Cfg, s.GetCategories(ctx), s.getCache())
+	pageCache := s.getCache()
+	if !selector.Day.IsZero() && !s.isToday(selector.Day) {
+		// the cache is kept per day, the content of other days is not cached
+		pageCache = nil
+	}
+
+	runner := NewAsyncRunner(&s.Cfg, s.GetCategories(ctx), pageCache)
 	return runner.Run(ctx, selector)
 }
 
@@ -46,6 +55,28 @@ func (s *Service) GetCategories(ctx context.Context) []models.Category {
 	return *s.categories.Get(ctx)
 }
 
+// GetStoredFile returns the file stored in the cache for the page namespace
+func (s *Service) GetStoredFile(nm cache.ItemNamespace, fileName string) ([]byte, bool) {
+	c := s.getCache()
+	if c == nil {
+		return nil, false
+	}
+
+	content := c.GetContent(cache.Item{Namespace: nm, FileName: fileName})
+	return content, len(content) != 0
+}
+
+// isToday whether the day is the current day in the configured timezone
+func (s *Service) isToday(day time.Time) bool {
+	today, err := s.Cfg.Day.ParseTargetDay("", time.Now())
+	return err == nil && today.Format(time.DateOnly) == day.Format(time.DateOnly)
+}
+
+// getCache returns the cache of the current day in the configured timezone
 func (s *Service) getCache() cache.Cache {
-	return cache.NewCache(s.Cfg.Cache, time.Now())
+	now := time.Now()
+	if loc, err := s.Cfg.Day.Location(); err == nil {
+		now = now.In(loc)
+	}
+	return cache.NewCache(s.Cfg.Cache, now)
 }
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pestanko/miniscrape/internal/cache"
+	"github.com/pestanko/miniscrape/internal/scraper"
+	"github.com/pestanko/miniscrape/internal/scraper/resolvers"
+	"github.com/pestanko/miniscrape/pkg/rest/webut"
+)
+
+// HandleStoredImage handler to serve the images stored by the image resolver
+func HandleStoredImage(service *scraper.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		category, page, fileName := chi.URLParam(req, "category"), chi.URLParam(req, "page"), chi.URLParam(req, "file")
+		if !isSafePathSegment(category) || !isSafePathSegment(page) || !isSafePathSegment(fileName) {
+			webut.WriteErrorResponse(w, http.StatusBadRequest, webut.ErrorDto{
+				Error:       "invalid_request",
+				ErrorDetail: "Invalid image path.",
+			})
+			return
+		}
+
+		contentType, ok := resolvers.StoredImageContentType(fileName)
+		if !ok {
+			webut.WriteErrorResponse(w, http.StatusNotFound, webut.ErrorDto{
+				Error:       "not_found",
+				ErrorDetail: "The image has not been found.",
+			})
+			return
+		}
+
+		nm := cache.NewNamespace(category, page)
+		content, ok := service.GetStoredFile(nm, fileName)
+		if !ok {
+			webut.WriteErrorResponse(w, http.StatusNotFound, webut.ErrorDto{
+				Error:       "not_found",
+				ErrorDetail: "The image has not been found.",
+			})
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	}
+}
+
+// isSafePathSegment whether the value is a single path segment, not the relative or hidden path
+func isSafePathSegment(value string) bool {
+	return value != "" &&
+		value == filepath.Base(value) &&
+		!strings.HasPrefix(value, ".") &&
+		!strings.ContainsAny(value, `/\`)
+}
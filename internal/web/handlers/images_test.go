@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pestanko/miniscrape/internal/cache"
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/scraper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleStoredImage(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	cfg := &config.AppConfig{Cache: config.CacheCfg{Enabled: true, Root: root}}
+	c := cache.NewCache(cfg.Cache, time.Now())
+	nm := cache.NewNamespace("food", "bistro")
+	assert.NoError(c.Store(cache.Item{Namespace: nm, FileName: "image-0.png"}, []byte("png")))
+	assert.NoError(c.Store(cache.Item{Namespace: nm, FileName: "image-1.html"}, []byte("<script>")))
+	assert.NoError(os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0o600))
+
+	router := chi.NewRouter()
+	router.Get("/images/{category}/{page}/{file}", HandleStoredImage(scraper.NewService(cfg)))
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{path: "/images/food/bistro/image-0.png", status: http.StatusOK},
+		{path: "/images/food/bistro/image-1.html", status: http.StatusNotFound},
+		{path: "/images/../../secret.txt", status: http.StatusBadRequest},
+		{path: "/images/food/./secret.txt", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		assert.Equal(tt.status, rec.Code, tt.path)
+		if tt.status == http.StatusOK {
+			assert.Equal("image/png", rec.Header().Get("Content-Type"))
+			assert.Equal("nosniff", rec.Header().Get("X-Content-Type-Options"))
+			assert.Equal("png", rec.Body.String())
+		}
+	}
+}
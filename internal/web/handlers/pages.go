@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/pestanko/miniscrape/internal/models"
 	"github.com/pestanko/miniscrape/internal/scraper"
@@ -20,6 +21,16 @@ func HandlePagesContent(service *scraper.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		selector := makeSelectorFromRequest(req)
 
+		day, err := service.Cfg.Day.ParseTargetDay(req.URL.Query().Get("day"), time.Now())
+		if err != nil {
+			webut.WriteErrorResponse(w, http.StatusBadRequest, webut.ErrorDto{
+				Error:       "invalid_day",
+				ErrorDetail: err.Error(),
+			})
+			return
+		}
+		selector.Day = day
+
 		results := service.Scrape(req.Context(), selector)
 
 		dto := make([]pageContentDto, len(results))
@@ -28,7 +39,11 @@ func HandlePagesContent(service *scraper.Service) http.HandlerFunc {
 			dto[i] = pageContentDto{
 				Content:  result.Content,
 				Status:   string(result.Status),
-				Resolver: result.Page.Resolver,
+				Resolver: result.ResolverName(),
+				Kind:     result.Kind,
+				Error:    makePageErrorDto(result.Error),
+				Menu:     result.Menu,
+				Source:   result.Source,
 				Page: pageContentPageDto{
 					PageName:     result.Page.Name,
 					PageCodeName: result.Page.CodeName,
@@ -48,9 +63,36 @@ type pageContentDto struct {
 	Content  string             `json:"content"`
 	Status   string             `json:"status"`
 	Resolver string             `json:"resolver"`
+	Kind     string             `json:"kind"`
+	Error    *pageErrorDto      `json:"error,omitempty"`
+	Menu     *models.Menu       `json:"menu,omitempty"`
+	Source   string             `json:"source,omitempty"`
 	Page     pageContentPageDto `json:"page"`
 }
 
+type pageErrorDto struct {
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"statusCode,omitempty"`
+}
+
+func makePageErrorDto(err *models.ScrapeError) *pageErrorDto {
+	if err == nil {
+		return nil
+	}
+
+	var message string
+	if err.Err != nil {
+		message = err.Err.Error()
+	}
+
+	return &pageErrorDto{
+		Kind:       string(err.Kind),
+		Message:    message,
+		StatusCode: err.StatusCode,
+	}
+}
+
 type pageContentPageDto struct {
 	PageName     string   `json:"name"`
 	PageCodeName string   `json:"codename"`
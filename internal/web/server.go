@@ -34,6 +34,7 @@ func registerRoutes(mux chi.Router, service *scraper.Service) {
 		r.Get("/categories", handlers.HandleCategories(service))
 		r.Get("/pages", handlers.HandlePages(service))
 		r.Get("/content", handlers.HandlePagesContent(service))
+		r.Get("/images/{category}/{page}/{file}", handlers.HandleStoredImage(service))
 
 		r.Route("/auth", func(r chi.Router) {
 			r.Post("/login", handlers.HandleAuthLogin(service))
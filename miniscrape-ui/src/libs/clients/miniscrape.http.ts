@@ -29,6 +29,7 @@ type ContentStatus = 'ok' | 'empty' | 'error';
 export type PageContentResponse = {
     content: string;
     resolver: string;
+    kind: string;
     status: ContentStatus;
     page: PageDetail;
 };
@@ -0,0 +1,61 @@
+// Package extension exposes the extension points of the miniscrape,
+// so the applications embedding it can register their own resolvers
+// without forking the repository.
+//
+// The resolver has to be registered before the categories are loaded,
+// preferably in the init function of the embedding application:
+//
+//	func init() {
+//		extension.MustRegisterResolver(extension.ResolverDefinition{
+//			Names: []string{"my-resolver"},
+//			Factory: func(page extension.Page) extension.PageResolver {
+//				return &myResolver{page: page}
+//			},
+//		})
+//	}
+package extension
+
+import (
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/resolvers"
+)
+
+// Page configuration of the scraped page
+type Page = models.Page
+
+// RunResult result of the page resolution
+type RunResult = models.RunResult
+
+// RunResultStatus status of the page resolution
+type RunResultStatus = models.RunResultStatus
+
+// ScrapeError typed error of the page resolution
+type ScrapeError = models.ScrapeError
+
+// PageResolver resolves the content of the page
+type PageResolver = resolvers.PageResolver
+
+// ResolverFactory creates a new instance of the resolver for the page
+type ResolverFactory = resolvers.ResolverFactory
+
+// ResolverDefinition describes the resolver to be registered
+type ResolverDefinition = resolvers.ResolverDefinition
+
+const (
+	// RunSuccess status OK
+	RunSuccess = models.RunSuccess
+	// RunError status ERROR
+	RunError = models.RunError
+	// RunEmpty status EMPTY
+	RunEmpty = models.RunEmpty
+)
+
+// RegisterResolver registers a custom resolver
+func RegisterResolver(def ResolverDefinition) error {
+	return resolvers.Register(def)
+}
+
+// MustRegisterResolver registers a custom resolver, panics on failure
+func MustRegisterResolver(def ResolverDefinition) {
+	resolvers.MustRegister(def)
+}
@@ -0,0 +1,42 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/pestanko/miniscrape/internal/config"
+	"github.com/pestanko/miniscrape/internal/models"
+	"github.com/pestanko/miniscrape/internal/scraper/resolvers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrapeReplayedPage(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(resolvers.ConfigureCassette(config.CassetteCfg{
+		Mode: config.CassetteModeReplay,
+		Dir:  "testdata/cassettes",
+	}))
+	defer func() {
+		_ = resolvers.ConfigureCassette(config.CassetteCfg{})
+	}()
+
+	page := models.Page{
+		CodeName: "bistro",
+		Name:     "Bistro",
+		URL:      "https://bistro.example/menu",
+		Resolver: "default",
+		Query:    "#menu",
+		Fetch:    config.FetchCfg{Retries: -1},
+	}
+
+	res := resolvers.NewPageResolver(page).Resolve(t.Context())
+
+	assert.Equal(models.RunSuccess, res.Status)
+	assert.Contains(res.Content, "Svíčková na smetaně 159 Kč")
+	assert.NotContains(res.Content, "Bistro Example")
+
+	page.URL = "https://bistro.example/unknown"
+	res = resolvers.NewPageResolver(page).Resolve(t.Context())
+
+	assert.Equal(models.RunError, res.Status)
+}